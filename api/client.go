@@ -0,0 +1,106 @@
+// Package api describes the API of a dis-redis Client as an interface, so that services can replace it with the mocks
+// in mocks/apimock in their tests.
+package api
+
+import (
+	"context"
+	"io/fs"
+	"iter"
+	"time"
+
+	disRedis "github.com/ONSdigital/dis-redis"
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+//go:generate moq -out ../mocks/apimock/client.go -pkg apimock . Client
+//go:generate moq -out ../mocks/apimock/leaderboard.go -pkg apimock .. Leaderboard
+//go:generate moq -out ../mocks/apimock/windowed_counter.go -pkg apimock .. WindowedCounter
+//go:generate moq -out ../mocks/apimock/subscription.go -pkg apimock .. Subscription
+//go:generate moq -out ../mocks/apimock/stream_consumer.go -pkg apimock .. StreamConsumer
+//go:generate moq -out ../mocks/apimock/local_cache.go -pkg apimock .. LocalCache
+
+// Client describes the API of a dis-redis *Client, so that services can depend on it rather than on go-redis and
+// replace it with apimock.ClientMock in their tests. The helpers it returns, such as a Leaderboard, are mocked by
+// apimock.LeaderboardMock and the like.
+type Client interface {
+	// Values
+	GetValue(ctx context.Context, key string, opts ...disRedis.CallOption) (string, error)
+	SetValue(ctx context.Context, key string, value interface{}, expiration time.Duration, opts ...disRedis.CallOption) error
+	DeleteValue(ctx context.Context, key string, opts ...disRedis.CallOption) error
+	GetValues(ctx context.Context, keys []string) (map[string]disRedis.KeyResult, error)
+	SetValues(ctx context.Context, values map[string]interface{}, expiration time.Duration) (map[string]error, error)
+	DeleteValues(ctx context.Context, keys []string) (map[string]error, error)
+	DeleteByPattern(ctx context.Context, pattern string, opts disRedis.DeleteByPatternOptions) (disRedis.DeleteByPatternResult, error)
+
+	// Keys
+	GetKeyValuePairs(ctx context.Context, matchPattern string, count int64, cursor uint64, opts ...disRedis.CallOption) (map[string]string, uint64, error)
+	GetKeyValuePairsWithOptions(ctx context.Context, matchPattern string, count int64, cursor uint64, opts disRedis.KeyValuePairsOptions) (map[string]disRedis.KeyValue, uint64, error)
+	GetTotalKeys(ctx context.Context) (int64, error)
+	CountKeys(ctx context.Context, pattern string) (int64, error)
+	ScanKeys(ctx context.Context, pattern string, opts ...disRedis.ScanOption) iter.Seq2[string, error]
+	ScanKeyValues(ctx context.Context, pattern string, opts ...disRedis.ScanOption) (iter.Seq2[string, string], func() error)
+
+	// Expiry
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+	ExpireAt(ctx context.Context, key string, tm time.Time) error
+	Persist(ctx context.Context, key string) (bool, error)
+	GetEx(ctx context.Context, key string, expiration time.Duration) (string, error)
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	SetXX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	SetKeepTTL(ctx context.Context, key string, value interface{}) error
+	SetGet(ctx context.Context, key string, value interface{}, expiration time.Duration) (string, bool, error)
+
+	// Counters
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	Decr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	IncrBy(ctx context.Context, key string, by int64, expiration time.Duration) (int64, error)
+	IncrByFloat(ctx context.Context, key string, by float64, expiration time.Duration) (float64, error)
+	NewWindowedCounter(name string, config disRedis.WindowedCounterConfig) (disRedis.WindowedCounter, error)
+
+	// Sets and sorted sets
+	AddSetMembers(ctx context.Context, key string, members ...interface{}) (int64, error)
+	RemoveSetMembers(ctx context.Context, key string, members ...interface{}) (int64, error)
+	GetSetMembers(ctx context.Context, key string) ([]string, error)
+	IsSetMember(ctx context.Context, key string, member interface{}) (bool, error)
+	AddSortedSetMembers(ctx context.Context, key string, members ...disRedis.ScoredMember) (int64, error)
+	RemoveSortedSetMembers(ctx context.Context, key string, members ...string) (int64, error)
+	IncrementSortedSetScore(ctx context.Context, key, member string, increment float64) (float64, error)
+	GetSortedSetRange(ctx context.Context, key string, start, stop int64, reverse bool) ([]disRedis.ScoredMember, error)
+	GetSortedSetRangeByScore(ctx context.Context, key, minScore, maxScore string, offset, count int64, reverse bool) ([]disRedis.ScoredMember, error)
+	GetSortedSetRangeByLex(ctx context.Context, key, minMember, maxMember string, offset, count int64, reverse bool) ([]string, error)
+	NewLeaderboard(name string, config disRedis.LeaderboardConfig) (disRedis.Leaderboard, error)
+
+	// Pub/sub and streams
+	Publish(ctx context.Context, channel string, message interface{}) (int64, error)
+	PublishSharded(ctx context.Context, channel string, message interface{}) (int64, error)
+	Subscribe(ctx context.Context, handler disRedis.PubSubHandler, channels ...string) (disRedis.Subscription, error)
+	PSubscribe(ctx context.Context, handler disRedis.PubSubHandler, patterns ...string) (disRedis.Subscription, error)
+	SSubscribe(ctx context.Context, handler disRedis.PubSubHandler, channels ...string) (disRedis.Subscription, error)
+	AddToStream(ctx context.Context, stream string, values map[string]interface{}, maxLen int64) (string, error)
+	NewStreamConsumer(config disRedis.StreamConsumerConfig, handler disRedis.StreamHandler) (disRedis.StreamConsumer, error)
+
+	// Scripts and transactions
+	RegisterScript(name, src string)
+	RegisterScriptsFS(fsys fs.FS, pattern string) error
+	LoadScripts(ctx context.Context) error
+	RunScript(ctx context.Context, name string, keys []string, args ...interface{}) (interface{}, error)
+	LoadFunctionLibrary(ctx context.Context, code string) (string, error)
+	CallFunction(ctx context.Context, function string, keys []string, args ...interface{}) (interface{}, error)
+	Transaction(ctx context.Context, keys []string, fn func(tx *disRedis.Tx) error) error
+
+	// Caching
+	NewLocalCache(ctx context.Context, config disRedis.LocalCacheConfig) (disRedis.LocalCache, error)
+	TrackingEnabled() bool
+	TrackingStats() disRedis.LocalCacheStats
+
+	// Health and lifecycle
+	Checker(ctx context.Context, state *health.CheckState) error
+	Ping(ctx context.Context) (int, error)
+	CircuitState() disRedis.CircuitState
+	FailOpenStats() disRedis.FailOpenStats
+	Close(ctx context.Context) error
+}
+
+// Ensure, that *disRedis.Client does implement Client.
+var _ Client = (*disRedis.Client)(nil)
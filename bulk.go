@@ -0,0 +1,297 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultPipelineBatchSize = 100
+
+// KeyResult is the outcome of a bulk read for a single key.
+type KeyResult struct {
+	Value string
+	Err   error
+}
+
+// GetValues retrieves the values for the given keys, pipelining the requests in batches. The result holds an entry for
+// every key, with ErrKeyNotFound for keys that do not exist and any other error for keys that could not be read.
+// An error is only returned if ctx is done before all batches have been sent.
+func (cli *Client) GetValues(ctx context.Context, keys []string) (map[string]KeyResult, error) {
+	results := make(map[string]KeyResult, len(keys))
+
+	err := cli.runBatches(ctx, keys, func(batch []string) {
+		cmds := make([]*redis.StringCmd, len(batch))
+		_, _ = cli.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range batch {
+				cmds[i] = pipe.Get(ctx, cli.key(key))
+			}
+			return nil
+		})
+
+		for i, key := range batch {
+			val, err := cmds[i].Result()
+			if errors.Is(err, redis.Nil) {
+				err = ErrKeyNotFound
+			} else if err != nil {
+				err = fmt.Errorf("error getting value for key %s: %w", key, err)
+			} else if val, err = cli.decodeValue(ctx, cli.key(key), val); err != nil {
+				err = fmt.Errorf("error decoding value for key %s: %w", key, err)
+			}
+			results[key] = KeyResult{Value: val, Err: err}
+		}
+	}, func(key string, err error) {
+		results[key] = KeyResult{Err: err}
+	})
+
+	return results, err
+}
+
+// SetValues sets the given key-value pairs with an optional expiration time, pipelining the requests in batches.
+// The result holds an entry for every key, which is nil if the key was set.
+// An error is only returned if ctx is done before all batches have been sent.
+func (cli *Client) SetValues(ctx context.Context, values map[string]interface{}, expiration time.Duration) (map[string]error, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	results := make(map[string]error, len(values))
+
+	err := cli.runBatches(ctx, keys, func(batch []string) {
+		cmds := make([]*redis.StatusCmd, len(batch))
+		_, _ = cli.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range batch {
+				value, err := cli.encodeValue(ctx, cli.key(key), values[key])
+				if err != nil {
+					results[key] = fmt.Errorf("error encoding value for key %s: %w", key, err)
+					continue
+				}
+				cmds[i] = pipe.Set(ctx, cli.key(key), value, expiration)
+			}
+			return nil
+		})
+
+		for i, key := range batch {
+			if cmds[i] == nil {
+				continue
+			}
+			results[key] = nil
+			if err := cmds[i].Err(); err != nil {
+				results[key] = fmt.Errorf("failed to set value in Redis: %w", err)
+			}
+		}
+	}, func(key string, err error) {
+		results[key] = err
+	})
+
+	return results, err
+}
+
+// DeleteValues deletes the given keys, pipelining the requests in batches. The result holds an entry for every key,
+// which is nil if the key was deleted or ErrKeyNotFound if it did not exist.
+// An error is only returned if ctx is done before all batches have been sent.
+func (cli *Client) DeleteValues(ctx context.Context, keys []string) (map[string]error, error) {
+	results := make(map[string]error, len(keys))
+
+	err := cli.runBatches(ctx, keys, func(batch []string) {
+		cmds := make([]*redis.IntCmd, len(batch))
+		_, _ = cli.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range batch {
+				cmds[i] = pipe.Del(ctx, cli.key(key))
+			}
+			return nil
+		})
+
+		for i, key := range batch {
+			deleted, err := cmds[i].Result()
+			switch {
+			case err != nil:
+				results[key] = fmt.Errorf("failed to delete key from Redis: %w", err)
+			case deleted == 0:
+				results[key] = ErrKeyNotFound
+			default:
+				results[key] = nil
+			}
+		}
+	}, func(key string, err error) {
+		results[key] = err
+	})
+
+	return results, err
+}
+
+// DeleteByPatternOptions controls DeleteByPattern.
+type DeleteByPatternOptions struct {
+	// DryRun counts the keys that would be deleted without deleting them.
+	DryRun bool
+
+	// MaxKeys is the maximum number of keys to delete, 0 means no limit. With a limit the matching keys are held in
+	// memory until the scan has finished, and are only deleted if there are no more than MaxKeys. Otherwise the scan
+	// stops as soon as the limit is exceeded and ErrMaxKeysExceeded is returned without deleting any keys.
+	MaxKeys int64
+}
+
+// DeleteByPatternResult holds the counts of keys matched and deleted by DeleteByPattern.
+type DeleteByPatternResult struct {
+	// Matched is the number of keys found by the scan, up to the page that exceeded MaxKeys if the scan stopped there.
+	// SCAN can return a key more than once while the keyspace is being resized, so it may include duplicates.
+	Matched int64
+
+	// Deleted is the number of keys removed. It is lower than Matched if keys expired or were deleted by someone else
+	// during the scan, and is always 0 for a dry run.
+	Deleted int64
+}
+
+// DeleteByPattern deletes every key matching pattern, within the client's namespace if it has a KeyPrefix. Keys are
+// scanned across every master of a cluster client and removed with UNLINK, which frees memory in the background, in
+// pipelined batches of the client's PipelineBatchSize so that redis is not blocked.
+//
+// The counts are returned along with any error, so the progress of a deletion that stopped part way is known.
+func (cli *Client) DeleteByPattern(ctx context.Context, pattern string, opts DeleteByPatternOptions) (DeleteByPatternResult, error) {
+	if opts.MaxKeys < 0 {
+		return DeleteByPatternResult{}, errors.New("MaxKeys cannot be negative")
+	}
+
+	var (
+		result    DeleteByPatternResult
+		pending   []scannedPage
+		deleteErr error
+	)
+
+	err := cli.scanPages(ctx, pattern, nil, func(node redis.UniversalClient, keys []string) bool {
+		result.Matched += int64(len(keys))
+
+		switch {
+		case opts.DryRun:
+		case opts.MaxKeys > 0:
+			// Keys are only unlinked once the scan has finished within MaxKeys, so none are deleted if it is exceeded
+			pending = append(pending, scannedPage{node: node, keys: keys})
+		default:
+			deleted, err := cli.unlinkKeys(ctx, node, keys)
+			result.Deleted += deleted
+			if err != nil {
+				deleteErr = err
+				return false
+			}
+		}
+
+		return opts.MaxKeys == 0 || result.Matched <= opts.MaxKeys
+	})
+	if err == nil {
+		err = deleteErr
+	}
+	if err == nil && opts.MaxKeys > 0 && result.Matched > opts.MaxKeys {
+		err = fmt.Errorf("%w: more than %d keys match", ErrMaxKeysExceeded, opts.MaxKeys)
+	}
+	for _, page := range pending {
+		if err != nil {
+			break
+		}
+
+		var deleted int64
+		deleted, err = cli.unlinkKeys(ctx, page.node, page.keys)
+		result.Deleted += deleted
+	}
+	if err != nil {
+		return result, fmt.Errorf("error deleting keys matching %s: %w", pattern, err)
+	}
+
+	return result, nil
+}
+
+// scannedPage is a page of keys scanned from a node
+type scannedPage struct {
+	node redis.UniversalClient
+	keys []string
+}
+
+// unlinkKeys removes keys from node with pipelined batches of UNLINK, returning the number of keys removed
+func (cli *Client) unlinkKeys(ctx context.Context, node redis.UniversalClient, keys []string) (int64, error) {
+	size := cli.pipelineBatchSize
+	if size <= 0 {
+		size = defaultPipelineBatchSize
+	}
+
+	var deleted int64
+
+	for start := 0; start < len(keys); start += size {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		batch := keys[start:min(start+size, len(keys))]
+
+		cmds := make([]*redis.IntCmd, len(batch))
+		_, err := node.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range batch {
+				cmds[i] = pipe.Unlink(ctx, key)
+			}
+			return nil
+		})
+
+		for _, cmd := range cmds {
+			deleted += cmd.Val()
+		}
+
+		if err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
+
+// runBatches splits keys into pipeline sized batches and runs each in turn. If ctx is done before all batches have
+// run, skip is called for every remaining key and the context error is returned.
+func (cli *Client) runBatches(ctx context.Context, keys []string, run func(batch []string), skip func(key string, err error)) error {
+	batches := cli.batchKeys(keys)
+
+	for i, batch := range batches {
+		if err := ctx.Err(); err != nil {
+			for _, remaining := range batches[i:] {
+				for _, key := range remaining {
+					skip(key, err)
+				}
+			}
+			return err
+		}
+
+		run(batch)
+	}
+
+	return nil
+}
+
+// batchKeys splits keys into batches of at most the pipeline batch size. On cluster clients the keys are ordered by
+// hash slot first so that each batch is sent to as few nodes as possible.
+func (cli *Client) batchKeys(keys []string) [][]string {
+	size := cli.pipelineBatchSize
+	if size <= 0 {
+		size = defaultPipelineBatchSize
+	}
+
+	if _, isCluster := cli.redisClient.(*redis.ClusterClient); isCluster {
+		sorted := make([]string, len(keys))
+		copy(sorted, keys)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return hashSlot(cli.key(sorted[i])) < hashSlot(cli.key(sorted[j]))
+		})
+		keys = sorted
+	}
+
+	batches := make([][]string, 0, (len(keys)+size-1)/size)
+	for start := 0; start < len(keys); start += size {
+		end := start + size
+		if end > len(keys) {
+			end = len(keys)
+		}
+		batches = append(batches, keys[start:end])
+	}
+
+	return batches
+}
@@ -0,0 +1,220 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_GetValues(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a redis client holding some values", t, func() {
+		redisClient, hook := newStubbedClient(func(cmd redis.Cmder) {
+			key := fmt.Sprint(cmd.Args()[1])
+			switch key {
+			case "missing":
+				cmd.SetErr(redis.Nil)
+			case "hash":
+				cmd.SetErr(errors.New("WRONGTYPE Operation against a key holding the wrong kind of value"))
+			default:
+				cmd.(*redis.StringCmd).SetVal("val_for_" + key)
+			}
+		})
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{PipelineBatchSize: 2}, redisClient)
+
+		Convey("When getting values for several keys", func() {
+			results, err := client.GetValues(ctx, []string{"key1", "key2", "missing", "hash", "key3"})
+
+			Convey("Then the keys are pipelined in batches", func() {
+				So(err, ShouldBeNil)
+				So(hook.pipelines, ShouldHaveLength, 3)
+			})
+
+			Convey("Then every key has its own result", func() {
+				So(results, ShouldHaveLength, 5)
+				So(results["key1"], ShouldResemble, KeyResult{Value: "val_for_key1"})
+				So(results["key3"], ShouldResemble, KeyResult{Value: "val_for_key3"})
+				So(results["missing"].Err, ShouldEqual, ErrKeyNotFound)
+				So(results["hash"].Err.Error(), ShouldContainSubstring, "WRONGTYPE")
+			})
+		})
+
+		Convey("When the context is cancelled", func() {
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			results, err := client.GetValues(cancelledCtx, []string{"key1", "key2"})
+
+			Convey("Then no requests are sent and every key reports the context error", func() {
+				So(err, ShouldEqual, context.Canceled)
+				So(hook.pipelines, ShouldBeEmpty)
+				So(results["key1"].Err, ShouldEqual, context.Canceled)
+				So(results["key2"].Err, ShouldEqual, context.Canceled)
+			})
+		})
+	})
+}
+
+func TestClient_SetValues(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a redis client that fails to set one key", t, func() {
+		redisClient, hook := newStubbedClient(func(cmd redis.Cmder) {
+			if cmd.Args()[1] == "readonly" {
+				cmd.SetErr(errors.New("READONLY You can't write against a read only replica"))
+				return
+			}
+			cmd.(*redis.StatusCmd).SetVal("OK")
+		})
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, redisClient)
+
+		Convey("When setting several values with an expiration", func() {
+			results, err := client.SetValues(ctx, map[string]interface{}{"key1": "a", "key2": "b", "readonly": "c"}, time.Minute)
+
+			Convey("Then they are sent in one pipeline with the expiration", func() {
+				So(err, ShouldBeNil)
+				So(hook.pipelines, ShouldHaveLength, 1)
+				for _, cmd := range hook.pipelines[0] {
+					So(cmd.Args(), ShouldContain, int64(60))
+				}
+			})
+
+			Convey("Then only the failed key has an error", func() {
+				So(results, ShouldHaveLength, 3)
+				So(results["key1"], ShouldBeNil)
+				So(results["key2"], ShouldBeNil)
+				So(results["readonly"].Error(), ShouldContainSubstring, "READONLY")
+			})
+		})
+	})
+}
+
+func TestClient_DeleteValues(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a redis client where one key does not exist", t, func() {
+		redisClient, _ := newStubbedClient(func(cmd redis.Cmder) {
+			if cmd.Args()[1] == "missing" {
+				cmd.(*redis.IntCmd).SetVal(0)
+				return
+			}
+			cmd.(*redis.IntCmd).SetVal(1)
+		})
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, redisClient)
+
+		Convey("When deleting several keys", func() {
+			results, err := client.DeleteValues(ctx, []string{"key1", "missing"})
+
+			Convey("Then the missing key reports that it was not found", func() {
+				So(err, ShouldBeNil)
+				So(results["key1"], ShouldBeNil)
+				So(results["missing"], ShouldEqual, ErrKeyNotFound)
+			})
+		})
+	})
+}
+
+func TestClient_batchKeys(t *testing.T) {
+	Convey("Given a cluster client with a batch size of two", t, func() {
+		client := &Client{
+			redisClient:       redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{testAddress}}),
+			pipelineBatchSize: 2,
+		}
+
+		Convey("When keys are batched", func() {
+			batches := client.batchKeys([]string{"foo", "hello", "{hello}:b", "somekey"})
+
+			Convey("Then they are ordered by hash slot", func() {
+				So(batches, ShouldResemble, [][]string{{"hello", "{hello}:b"}, {"somekey", "foo"}})
+			})
+		})
+	})
+}
+
+func TestClient_DeleteByPattern(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a redis client with five keys matching a pattern over two scan pages", t, func() {
+		var unlinked []string
+
+		redisClient, hook := newStubbedClient(func(cmd redis.Cmder) {
+			switch cmd := cmd.(type) {
+			case *redis.ScanCmd:
+				if cmd.Args()[1] == uint64(0) {
+					cmd.SetVal([]string{"cache:1", "cache:2", "cache:3"}, 4)
+				} else {
+					cmd.SetVal([]string{"cache:4", "cache:expired"}, 0)
+				}
+			case *redis.IntCmd:
+				key := fmt.Sprint(cmd.Args()[1])
+				unlinked = append(unlinked, key)
+				if key != "cache:expired" {
+					cmd.SetVal(1)
+				}
+			}
+		})
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{PipelineBatchSize: 2}, redisClient)
+
+		Convey("When the keys are deleted", func() {
+			result, err := client.DeleteByPattern(ctx, "cache:*", DeleteByPatternOptions{})
+
+			Convey("Then every key is unlinked in pipelined batches and the counts are returned", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldResemble, DeleteByPatternResult{Matched: 5, Deleted: 4})
+				So(unlinked, ShouldResemble, []string{"cache:1", "cache:2", "cache:3", "cache:4", "cache:expired"})
+				So(hook.pipelines, ShouldHaveLength, 3)
+				So(hook.pipelines[0][0].Name(), ShouldEqual, "unlink")
+			})
+		})
+
+		Convey("When the deletion is a dry run", func() {
+			result, err := client.DeleteByPattern(ctx, "cache:*", DeleteByPatternOptions{DryRun: true})
+
+			Convey("Then the matching keys are counted but none are deleted", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldResemble, DeleteByPatternResult{Matched: 5})
+				So(unlinked, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When more keys match than MaxKeys", func() {
+			result, err := client.DeleteByPattern(ctx, "cache:*", DeleteByPatternOptions{MaxKeys: 2})
+
+			Convey("Then the scan stops, no keys are deleted and ErrMaxKeysExceeded is returned", func() {
+				So(err, ShouldWrap, ErrMaxKeysExceeded)
+				So(result, ShouldResemble, DeleteByPatternResult{Matched: 3})
+				So(unlinked, ShouldBeEmpty)
+				So(hook.pipelines, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When no more keys match than MaxKeys", func() {
+			result, err := client.DeleteByPattern(ctx, "cache:*", DeleteByPatternOptions{MaxKeys: 5})
+
+			Convey("Then every key is unlinked once the scan has finished", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldResemble, DeleteByPatternResult{Matched: 5, Deleted: 4})
+				So(unlinked, ShouldResemble, []string{"cache:1", "cache:2", "cache:3", "cache:4", "cache:expired"})
+			})
+		})
+
+		Convey("When MaxKeys is negative", func() {
+			_, err := client.DeleteByPattern(ctx, "cache:*", DeleteByPatternOptions{MaxKeys: -1})
+
+			Convey("Then an error is returned without scanning", func() {
+				So(err, ShouldNotBeNil)
+				So(unlinked, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	callRetryMinBackoff = 10 * time.Millisecond
+	callRetryMaxBackoff = time.Second
+)
+
+// CallOption configures a single call to the client, such as GetValue or SetValue, overriding the client's settings
+// so that latency-critical paths can use tighter deadlines than background jobs sharing the same client.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout     time.Duration
+	retries     int
+	replica     *bool
+	bypassCache bool
+	failOpen    *bool
+}
+
+// callOptionsKey is the context key of the options of the call in progress
+type callOptionsKey struct{}
+
+// WithTimeout limits the call, including any retries, to d. The earlier of d and the context's deadline is used.
+func WithTimeout(d time.Duration) CallOption {
+	return func(opts *callOptions) {
+		opts.timeout = d
+	}
+}
+
+// WithRetries retries the call up to n times with backoff if it fails with an error for which IsRetryable is true.
+// These retries are in addition to go-redis's own retries of commands on broken connections.
+func WithRetries(n int) CallOption {
+	return func(opts *callOptions) {
+		opts.retries = n
+	}
+}
+
+// WithReplicaRead reads from a replica if enabled, or from the primary if not, overriding the client's configured
+// routing. Reading from the primary ensures that a read sees the caller's own earlier writes. Writes always go to the
+// primary, and the option has no effect if the client has no replicas to read from.
+func WithReplicaRead(enabled bool) CallOption {
+	return func(opts *callOptions) {
+		opts.replica = &enabled
+	}
+}
+
+// WithoutCache reads the value from redis rather than from the client-side cache enabled by ClientConfig.Tracking or
+// a LocalCache.
+func WithoutCache() CallOption {
+	return func(opts *callOptions) {
+		opts.bypassCache = true
+	}
+}
+
+// WithFailOpenMode enables or disables fail-open mode for the call, as WithFailOpen does for a context.
+func WithFailOpenMode(enabled bool) CallOption {
+	return func(opts *callOptions) {
+		opts.failOpen = &enabled
+	}
+}
+
+// withCallOptions returns a context carrying opts, merged with the options of any call already in progress, and with
+// the call's timeout applied. The returned cancel function must be called when the call ends.
+func withCallOptions(ctx context.Context, opts []CallOption) (context.Context, context.CancelFunc) {
+	if len(opts) == 0 {
+		return ctx, func() {}
+	}
+
+	o := callOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx = context.WithValue(ctx, callOptionsKey{}, o)
+
+	if o.failOpen != nil {
+		ctx = WithFailOpen(ctx, *o.failOpen)
+	}
+
+	if o.timeout > 0 {
+		return context.WithTimeout(ctx, o.timeout)
+	}
+
+	return ctx, func() {}
+}
+
+// callOptionsFrom returns the options of the call in progress
+func callOptionsFrom(ctx context.Context) callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return o
+}
+
+// retry calls fn, calling it again with backoff while it fails with a retryable error and the call has retries left
+func retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	retries := callOptionsFrom(ctx).retries
+	backoff := callRetryMinBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= retries || !IsRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, callRetryMaxBackoff)
+	}
+}
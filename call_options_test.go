@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_CallOptions(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mocked Redis client that fails a number of times before succeeding", t, func() {
+		var failures int
+		var replyErr error
+		var deadline time.Time
+		var hasDeadline bool
+		var gets int
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+				gets++
+				deadline, hasDeadline = ctx.Deadline()
+				cmd := redis.NewStringCmd(ctx, "get", key)
+				if gets <= failures {
+					cmd.SetErr(replyErr)
+				} else {
+					cmd.SetVal("val_for_" + key)
+				}
+				return cmd
+			},
+			SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				return redis.NewStatusCmd(ctx, "set", key)
+			},
+		}
+
+		client := &Client{redisClient: mockRedisClient}
+
+		Convey("When a value is read with a timeout", func() {
+			start := time.Now()
+			_, err := client.GetValue(ctx, "key", WithTimeout(50*time.Millisecond))
+
+			Convey("Then redis is called with the deadline", func() {
+				So(err, ShouldBeNil)
+				So(hasDeadline, ShouldBeTrue)
+				So(deadline, ShouldHappenWithin, 60*time.Millisecond, start)
+			})
+		})
+
+		Convey("When a value is read without options", func() {
+			_, err := client.GetValue(ctx, "key")
+
+			Convey("Then redis is called with the caller's context", func() {
+				So(err, ShouldBeNil)
+				So(hasDeadline, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a read that fails with connection errors is retried", func() {
+			failures, replyErr = 2, io.EOF
+			val, err := client.GetValue(ctx, "key", WithRetries(2))
+
+			Convey("Then the value is returned once redis succeeds", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "val_for_key")
+				So(gets, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When a read fails more times than it is retried", func() {
+			failures, replyErr = 3, io.EOF
+			_, err := client.GetValue(ctx, "key", WithRetries(1))
+
+			Convey("Then the last error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(gets, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When a read fails with a permanent error", func() {
+			failures, replyErr = 1, testRedisError("WRONGTYPE Operation against a key holding the wrong kind of value")
+			_, err := client.GetValue(ctx, "key", WithRetries(3))
+
+			Convey("Then it is not retried", func() {
+				So(err, ShouldNotBeNil)
+				So(gets, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When a failing read is made in fail-open mode", func() {
+			failures, replyErr = 1, io.EOF
+			_, err := client.GetValue(ctx, "key", WithFailOpenMode(true))
+
+			Convey("Then it is returned as a miss", func() {
+				So(err, ShouldEqual, ErrKeyNotFound)
+			})
+		})
+	})
+}
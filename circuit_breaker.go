@@ -0,0 +1,333 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultCircuitFailureRate      = 0.5
+	defaultCircuitMinRequests      = 20
+	defaultCircuitWindow           = 10 * time.Second
+	defaultCircuitOpenTimeout      = 30 * time.Second
+	defaultCircuitHalfOpenRequests = 1
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState string
+
+// Circuit breaker states
+const (
+	// CircuitClosed sends every command to redis
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fails every command with ErrCircuitOpen without contacting redis
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen sends a limited number of trial commands to redis to check whether it has recovered
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreakerConfig configures the circuit breaker around a client's redis commands. Only failures that suggest
+// redis is unavailable, such as timeouts and connection errors, are counted. Error replies from redis and missing keys
+// are successful calls.
+type CircuitBreakerConfig struct {
+	// FailureRate is the fraction of failed calls within a Window, between 0 and 1, that opens the circuit.
+	// Defaults to 0.5.
+	FailureRate float64
+	// MinRequests is the number of calls within a Window before the failure rate is considered. Defaults to 20.
+	MinRequests int
+	// Window is the period over which calls are counted. Counts are reset at the end of each window. Defaults to
+	// 10 seconds.
+	Window time.Duration
+	// OpenTimeout is how long the circuit stays open before trial calls are allowed. Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of trial calls allowed while half-open, all of which must succeed to close the
+	// circuit. Defaults to 1.
+	HalfOpenRequests int
+	// OnStateChange is called whenever the circuit changes state.
+	OnStateChange func(event CircuitBreakerEvent)
+}
+
+// CircuitBreakerEvent describes a change of circuit state.
+type CircuitBreakerEvent struct {
+	From CircuitState
+	To   CircuitState
+	At   time.Time
+}
+
+// Validate will validate that the circuit breaker config is usable
+func (c *CircuitBreakerConfig) Validate() error {
+	if c.FailureRate < 0 || c.FailureRate > 1 {
+		return errors.New("failure rate must be between 0 and 1")
+	}
+
+	if c.MinRequests < 0 {
+		return errors.New("min requests cannot be negative")
+	}
+
+	if c.Window < 0 {
+		return errors.New("window cannot be negative")
+	}
+
+	if c.OpenTimeout < 0 {
+		return errors.New("open timeout cannot be negative")
+	}
+
+	if c.HalfOpenRequests < 0 {
+		return errors.New("half open requests cannot be negative")
+	}
+
+	return nil
+}
+
+// circuitBreaker is a go-redis hook failing commands fast while redis is unavailable
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu          sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	trials      int
+	successes   int
+}
+
+// newCircuitBreaker returns a closed circuit breaker, applying defaults to config
+func newCircuitBreaker(config CircuitBreakerConfig) *circuitBreaker {
+	if config.FailureRate == 0 {
+		config.FailureRate = defaultCircuitFailureRate
+	}
+
+	if config.MinRequests == 0 {
+		config.MinRequests = defaultCircuitMinRequests
+	}
+
+	if config.Window == 0 {
+		config.Window = defaultCircuitWindow
+	}
+
+	if config.OpenTimeout == 0 {
+		config.OpenTimeout = defaultCircuitOpenTimeout
+	}
+
+	if config.HalfOpenRequests == 0 {
+		config.HalfOpenRequests = defaultCircuitHalfOpenRequests
+	}
+
+	return &circuitBreaker{
+		config: config,
+		now:    time.Now,
+		state:  CircuitClosed,
+	}
+}
+
+// DialHook does not change how connections are made
+func (b *circuitBreaker) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook fails the command with ErrCircuitOpen if the circuit is open, otherwise it records the command's outcome
+func (b *circuitBreaker) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if counted(ctx) {
+			return next(ctx, cmd)
+		}
+
+		if err := b.allow(ctx); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+
+		err := next(withCounted(ctx), cmd)
+		b.record(ctx, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook treats a pipeline as a single call, failing every command with ErrCircuitOpen if the circuit is open
+func (b *circuitBreaker) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if counted(ctx) {
+			return next(ctx, cmds)
+		}
+
+		if err := b.allow(ctx); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+
+		err := next(withCounted(ctx), cmds)
+		b.record(ctx, err)
+		return err
+	}
+}
+
+// countedKey is the context key marking a call that the circuit breaker has already let through
+type countedKey struct{}
+
+// withCounted marks ctx as belonging to a call that the circuit breaker has let through, so that the hook of a cluster
+// node the call is sent to does not count it again
+func withCounted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, countedKey{}, true)
+}
+
+// counted reports whether ctx belongs to a call that the circuit breaker has already let through
+func counted(ctx context.Context) bool {
+	marked, _ := ctx.Value(countedKey{}).(bool)
+	return marked
+}
+
+// State returns the current state of the circuit. An open circuit whose timeout has passed is reported as half-open,
+// as the next call is let through as a trial, even though it only moves to half-open when that call is made.
+func (b *circuitBreaker) State() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && b.openTimeoutPassed() {
+		return CircuitHalfOpen
+	}
+
+	return b.state
+}
+
+// openTimeoutPassed reports whether the circuit has been open for its timeout, the caller must hold b.mu
+func (b *circuitBreaker) openTimeoutPassed() bool {
+	return !b.now().Before(b.openedAt.Add(b.config.OpenTimeout))
+}
+
+// allow returns ErrCircuitOpen if a call cannot be made, moving an open circuit to half-open once its timeout has passed
+func (b *circuitBreaker) allow(ctx context.Context) error {
+	b.mu.Lock()
+
+	var event *CircuitBreakerEvent
+
+	if b.state == CircuitOpen && b.openTimeoutPassed() {
+		event = b.transition(CircuitHalfOpen)
+	}
+
+	var err error
+	switch b.state {
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.trials >= b.config.HalfOpenRequests {
+			err = ErrCircuitOpen
+		} else {
+			b.trials++
+		}
+	}
+
+	b.mu.Unlock()
+
+	b.notify(ctx, event)
+
+	return err
+}
+
+// record counts the outcome of a call, opening or closing the circuit if needed
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	failed := isUnavailable(err)
+
+	b.mu.Lock()
+
+	var event *CircuitBreakerEvent
+
+	switch b.state {
+	case CircuitClosed:
+		now := b.now()
+		if now.Sub(b.windowStart) >= b.config.Window {
+			b.windowStart = now
+			b.requests = 0
+			b.failures = 0
+		}
+
+		b.requests++
+		if failed {
+			b.failures++
+		}
+
+		if b.requests >= b.config.MinRequests && float64(b.failures) >= b.config.FailureRate*float64(b.requests) {
+			event = b.transition(CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		if failed {
+			event = b.transition(CircuitOpen)
+			break
+		}
+
+		b.successes++
+		if b.successes >= b.config.HalfOpenRequests {
+			event = b.transition(CircuitClosed)
+		}
+	}
+
+	b.mu.Unlock()
+
+	b.notify(ctx, event)
+}
+
+// transition changes the state of the circuit and resets its counts, the caller must hold b.mu
+func (b *circuitBreaker) transition(to CircuitState) *CircuitBreakerEvent {
+	event := &CircuitBreakerEvent{From: b.state, To: to, At: b.now()}
+
+	b.state = to
+	b.windowStart = event.At
+	b.requests = 0
+	b.failures = 0
+	b.trials = 0
+	b.successes = 0
+
+	if to == CircuitOpen {
+		b.openedAt = event.At
+	}
+
+	return event
+}
+
+// notify logs a change of state and passes it to the OnStateChange callback
+func (b *circuitBreaker) notify(ctx context.Context, event *CircuitBreakerEvent) {
+	if event == nil {
+		return
+	}
+
+	logData := log.Data{"from": event.From, "to": event.To}
+	if event.To == CircuitOpen {
+		log.Warn(ctx, "redis circuit breaker opened", logData)
+	} else {
+		log.Info(ctx, "redis circuit breaker changed state", logData)
+	}
+
+	if b.config.OnStateChange != nil {
+		b.config.OnStateChange(*event)
+	}
+}
+
+// isUnavailable reports whether err suggests that redis cannot be reached, such as a timeout or connection error,
+// rather than being a reply from redis or the caller giving up
+func isUnavailable(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) || errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	var redisErr redis.Error
+	return !errors.As(err, &redisErr)
+}
+
+// CircuitState returns the state of the client's circuit breaker. It is always CircuitClosed if
+// ClientConfig.CircuitBreaker is not set.
+func (cli *Client) CircuitState() CircuitState {
+	return cli.breaker.State()
+}
@@ -0,0 +1,143 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a client with a circuit breaker in front of a redis server", t, func() {
+		var replyErr error = errors.New("dial tcp: i/o timeout")
+		var calls int
+
+		var events []CircuitBreakerEvent
+		config := &ClientConfig{CircuitBreaker: &CircuitBreakerConfig{
+			FailureRate: 0.5,
+			MinRequests: 4,
+			OpenTimeout: time.Minute,
+			OnStateChange: func(event CircuitBreakerEvent) {
+				events = append(events, event)
+			},
+		}}
+
+		redisClient := redis.NewClient(&redis.Options{Addr: testAddress})
+		client := NewClientWithCustomClient(ctx, config, redisClient)
+		redisClient.AddHook(&stubHook{respond: func(cmd redis.Cmder) {
+			calls++
+			switch cmd := cmd.(type) {
+			case *redis.StringCmd:
+				cmd.SetVal("value")
+			case *redis.StatusCmd:
+				cmd.SetVal("PONG")
+			}
+			if replyErr != nil {
+				cmd.SetErr(replyErr)
+			}
+		}})
+
+		now := time.Now()
+		client.breaker.now = func() time.Time { return now }
+
+		Convey("When redis replies with errors", func() {
+			replyErr = testRedisError("WRONGTYPE Operation against a key holding the wrong kind of value")
+			for range 5 {
+				_, _ = client.GetValue(ctx, "key")
+			}
+
+			Convey("Then the circuit stays closed as redis is available", func() {
+				So(client.CircuitState(), ShouldEqual, CircuitClosed)
+				So(events, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When enough calls fail with connection errors", func() {
+			for range 4 {
+				_, _ = client.GetValue(ctx, "key")
+			}
+			callsWhenOpened := calls
+
+			_, err := client.GetValue(ctx, "key")
+
+			Convey("Then the circuit opens and calls fail fast without contacting redis", func() {
+				So(client.CircuitState(), ShouldEqual, CircuitOpen)
+				So(errors.Is(err, ErrCircuitOpen), ShouldBeTrue)
+				So(calls, ShouldEqual, callsWhenOpened)
+				So(events, ShouldHaveLength, 1)
+				So(events[0].From, ShouldEqual, CircuitClosed)
+				So(events[0].To, ShouldEqual, CircuitOpen)
+			})
+
+			Convey("Then the health check is critical", func() {
+				checkState := health.NewCheckState("dis-redis-test")
+				So(client.Checker(ctx, checkState), ShouldBeNil)
+				So(checkState.Status(), ShouldEqual, health.StatusCritical)
+				So(checkState.Message(), ShouldEqual, MsgCircuitOpen)
+				So(checkState.StatusCode(), ShouldEqual, http.StatusServiceUnavailable)
+			})
+
+			Convey("And redis recovers after the open timeout", func() {
+				replyErr = nil
+				now = now.Add(time.Minute)
+
+				val, err := client.GetValue(ctx, "key")
+
+				Convey("Then a trial call is made and the circuit closes", func() {
+					So(err, ShouldBeNil)
+					So(val, ShouldEqual, "value")
+					So(client.CircuitState(), ShouldEqual, CircuitClosed)
+					So(events, ShouldHaveLength, 3)
+					So(events[1].To, ShouldEqual, CircuitHalfOpen)
+					So(events[2].To, ShouldEqual, CircuitClosed)
+				})
+			})
+
+			Convey("And redis recovers and the open timeout passes without any calls", func() {
+				replyErr = nil
+				now = now.Add(time.Minute)
+
+				state := client.CircuitState()
+				checkState := health.NewCheckState("dis-redis-test")
+				err := client.Checker(ctx, checkState)
+
+				Convey("Then the health check's ping is the trial call and the check recovers", func() {
+					So(state, ShouldEqual, CircuitHalfOpen)
+					So(err, ShouldBeNil)
+					So(checkState.Status(), ShouldEqual, health.StatusOK)
+					So(checkState.Message(), ShouldEqual, MsgHealthy)
+					So(client.CircuitState(), ShouldEqual, CircuitClosed)
+					So(calls, ShouldEqual, callsWhenOpened+1)
+				})
+			})
+
+			Convey("And the trial call after the open timeout fails", func() {
+				now = now.Add(time.Minute)
+
+				_, _ = client.GetValue(ctx, "key")
+
+				Convey("Then the circuit opens again", func() {
+					So(client.CircuitState(), ShouldEqual, CircuitOpen)
+					So(events, ShouldHaveLength, 3)
+					So(events[2].From, ShouldEqual, CircuitHalfOpen)
+					So(events[2].To, ShouldEqual, CircuitOpen)
+				})
+			})
+		})
+	})
+
+	Convey("Given a client without a circuit breaker", t, func() {
+		client := &Client{}
+
+		Convey("Then the circuit is always closed", func() {
+			So(client.CircuitState(), ShouldEqual, CircuitClosed)
+		})
+	})
+}
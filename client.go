@@ -4,17 +4,49 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
 type Client struct {
-	redisClient redis.UniversalClient
+	redisClient           redis.UniversalClient
+	pipelineBatchSize     int
+	transactionMaxRetries int
+	keyPrefix             string
+	scanCount             int64
+	compression           Compression
+	compressionThreshold  int
+	keyProvider           KeyProvider
+	readerClient          redis.UniversalClient
+	replicaReads          bool
+	breaker               *circuitBreaker
+	failOpenConfig        *FailOpenConfig
+	failOpenStats         failOpenStats
+	writeBuffer           *writeBuffer
+	tracker               *tracker
+
+	mu            sync.Mutex
+	closers       map[interface{}]func(context.Context) error
+	subscriptions map[*subscription]struct{}
+	scripts       map[string]*redis.Script
 }
 
 var (
-	ErrKeyNotFound = errors.New("key not found")
+	ErrKeyNotFound         = errors.New("key not found")
+	ErrCrossSlot           = errors.New("keys do not hash to the same cluster slot")
+	ErrTransactionConflict = errors.New("transaction aborted as watched keys were modified")
+	ErrInvalidCursor       = errors.New("cursor does not belong to a node of the cluster")
+	ErrWrongType           = errors.New("key holds the wrong kind of value")
+	ErrMaxKeysExceeded     = errors.New("more keys match than the maximum allowed")
+	ErrCircuitOpen         = errors.New("circuit breaker is open")
+	ErrTimeout             = errors.New("redis did not respond in time")
+	ErrConnection          = errors.New("could not communicate with redis")
+	ErrAuth                = errors.New("redis rejected the client's credentials or permissions")
+	ErrReadOnly            = errors.New("redis node is read only")
+	ErrOutOfMemory         = errors.New("redis is out of memory")
 )
 
 // NewClusterClient returns a new Cluster Client with the provided config
@@ -34,14 +66,91 @@ func NewClient(ctx context.Context, clientConfig *ClientConfig) (*Client, error)
 		return nil, fmt.Errorf("error generating client: %w", err)
 	}
 
-	return NewClientWithCustomClient(ctx, clientConfig, client), nil
+	cli := NewClientWithCustomClient(ctx, clientConfig, client)
+
+	if clientConfig.ReaderAddress != "" {
+		reader, err := generateReaderClient(ctx, clientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error generating reader client: %w", err)
+		}
+		cli.setReader(reader)
+	}
+
+	return cli, nil
 }
 
-// NewClientWithCustomClient returns a new Client with the provided Redis Client
+// NewClientWithCustomClient returns a new Client with the provided Redis Client. A cluster client should not have been
+// used before it is provided, as the client's hooks are only added to the nodes it connects to afterwards.
 func NewClientWithCustomClient(ctx context.Context, clientConfig *ClientConfig, client redis.UniversalClient) *Client {
-	return &Client{
+	cli := &Client{
 		redisClient: client,
 	}
+
+	if clientConfig != nil {
+		cli.pipelineBatchSize = clientConfig.PipelineBatchSize
+		cli.transactionMaxRetries = clientConfig.TransactionMaxRetries
+		cli.keyPrefix = clientConfig.KeyPrefix
+		cli.scanCount = clientConfig.ScanCount
+		cli.compression = clientConfig.Compression
+		cli.compressionThreshold = clientConfig.CompressionThreshold
+		cli.keyProvider = clientConfig.KeyProvider
+		cli.replicaReads = clientConfig.ReadOnly || clientConfig.RouteByLatency
+
+		if clientConfig.CircuitBreaker != nil {
+			cli.breaker = newCircuitBreaker(*clientConfig.CircuitBreaker)
+		}
+
+		if clientConfig.FailOpen != nil {
+			cli.failOpenConfig = clientConfig.FailOpen
+			if clientConfig.FailOpen.WriteBufferSize > 0 {
+				cli.writeBuffer = newWriteBuffer(cli, clientConfig.FailOpen.WriteBufferSize, clientConfig.FailOpen.RetryInterval)
+			}
+		}
+
+		if clientConfig.Tracking != nil {
+			cli.tracker = newTracker(*clientConfig.Tracking, clientConfig.KeyPrefix, cli.addHooks)
+			cli.addCloser(cli.tracker, cli.tracker.close)
+		}
+	}
+
+	cli.addHooks(client)
+
+	return cli
+}
+
+// addHooks adds the client's hooks to a go-redis client it sends commands to
+func (cli *Client) addHooks(client redis.UniversalClient) {
+	// Errors from go-redis clients are wrapped with the matching sentinel errors, such as ErrTimeout. Values cached by
+	// the tracker, which only caches reads made through a client or cluster client, are evicted after every write.
+	switch client := client.(type) {
+	case *redis.Client:
+		client.AddHook(errorClassifier{})
+		if cli.tracker != nil {
+			client.AddHook(cli.tracker)
+		}
+	case *redis.Ring:
+		client.AddHook(errorClassifier{})
+	case *redis.ClusterClient:
+		client.AddHook(errorClassifier{})
+		if cli.tracker != nil {
+			client.AddHook(cli.tracker)
+		}
+		// Scans and other commands sent to a node directly bypass the cluster client's hooks. The breaker does not
+		// count commands sent through the cluster client again when they reach the node.
+		client.OnNewNode(func(node *redis.Client) {
+			node.AddHook(errorClassifier{})
+			if cli.breaker != nil {
+				node.AddHook(cli.breaker)
+			}
+			if cli.tracker != nil {
+				node.AddHook(cli.tracker)
+			}
+		})
+	}
+
+	if cli.breaker != nil {
+		client.AddHook(cli.breaker)
+	}
 }
 
 // generateClusterClient creates a Redis Cluster Client using the provided configuration
@@ -51,19 +160,18 @@ func generateClusterClient(ctx context.Context, clientConfig *ClientConfig) (red
 		return nil, fmt.Errorf("error getting client config: %w", err)
 	}
 
-	clusterClient := redis.NewClusterClient(&redis.ClusterOptions{
-		Addrs:    []string{options.Addr},
-		Username: options.Username,
-		NewClient: func(opt *redis.Options) *redis.Client {
-			return redis.NewClient(&redis.Options{
-				Addr:                       opt.Addr,
-				CredentialsProviderContext: options.CredentialsProviderContext,
-				TLSConfig:                  options.TLSConfig,
-			})
-		},
-	})
+	// Node clients are created from the cluster options, so that replicas are put in read only mode when ReadOnly is set
+	clusterOptions := &redis.ClusterOptions{
+		Addrs:                      []string{options.Addr},
+		Username:                   options.Username,
+		Protocol:                   options.Protocol,
+		CredentialsProviderContext: options.CredentialsProviderContext,
+		TLSConfig:                  options.TLSConfig,
+		ReadOnly:                   clientConfig.ReadOnly,
+		RouteByLatency:             clientConfig.RouteByLatency,
+	}
 
-	return clusterClient, nil
+	return redis.NewClusterClient(clusterOptions), nil
 }
 
 // generateClient creates a Redis Client using the provided configuration
@@ -76,66 +184,187 @@ func generateClient(ctx context.Context, clientConfig *ClientConfig) (redis.Univ
 	return redis.NewClient(options), nil
 }
 
-// Close the redis client connection
+// generateReaderClient creates a Redis Client for the configured reader endpoint
+func generateReaderClient(ctx context.Context, clientConfig *ClientConfig) (redis.UniversalClient, error) {
+	options, err := clientConfig.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client config: %w", err)
+	}
+
+	options.Addr = clientConfig.ReaderAddress
+
+	return redis.NewClient(options), nil
+}
+
+// Close stops any background workers started from the client, such as stream consumers, waiting for them
+// until ctx is done, and then closes the redis client connection
 func (cli *Client) Close(ctx context.Context) error {
-	return cli.redisClient.Close()
+	cli.mu.Lock()
+	closers := make([]func(context.Context) error, 0, len(cli.closers))
+	for _, closer := range cli.closers {
+		closers = append(closers, closer)
+	}
+	cli.mu.Unlock()
+
+	var errs []error
+	for _, closer := range closers {
+		if err := closer(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := cli.redisClient.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// addCloser registers a function, keyed by its owner, that is called when the client is closed
+func (cli *Client) addCloser(owner interface{}, closer func(context.Context) error) {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+
+	if cli.closers == nil {
+		cli.closers = make(map[interface{}]func(context.Context) error)
+	}
+	cli.closers[owner] = closer
+}
+
+// removeCloser deregisters the closer registered by owner
+func (cli *Client) removeCloser(owner interface{}) {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+
+	delete(cli.closers, owner)
 }
 
 // GetValue retrieves the value for a given key from Redis and returns it as a string.
-func (cli *Client) GetValue(ctx context.Context, key string) (string, error) {
-	val, err := cli.redisClient.Get(ctx, key).Result()
+// If ClientConfig.Tracking is set, the value is served from memory until redis reports that the key has changed.
+// In fail-open mode any error is returned as ErrKeyNotFound.
+func (cli *Client) GetValue(ctx context.Context, key string, opts ...CallOption) (val string, err error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	err = retry(ctx, func(ctx context.Context) error {
+		if node := cli.trackingNode(ctx, key); node != nil {
+			val, err = cli.getTrackedValue(ctx, node, key)
+		} else {
+			val, err = cli.getValue(ctx, key)
+		}
+		return err
+	})
+
+	if cli.suppressRead(ctx, key, err) {
+		return "", ErrKeyNotFound
+	}
+
+	return val, err
+}
+
+// getValue reads the value of key from redis
+func (cli *Client) getValue(ctx context.Context, key string) (string, error) {
+	return cli.getValueFrom(ctx, cli.readClient(ctx, cli.key(key)), key)
+}
+
+// getValueFrom reads the value of key with client
+func (cli *Client) getValueFrom(ctx context.Context, client redis.UniversalClient, key string) (string, error) {
+	val, err := client.Get(ctx, cli.key(key)).Result()
 	if errors.Is(err, redis.Nil) {
 		return "", ErrKeyNotFound
 	} else if err != nil {
 		return "", fmt.Errorf("error getting value for key %s: %w", key, err)
 	}
 
+	val, err = cli.decodeValue(ctx, cli.key(key), val)
+	if err != nil {
+		return "", fmt.Errorf("error decoding value for key %s: %w", key, err)
+	}
+
 	return val, nil
 }
 
 // GetKeyValuePairs retrieves a set of key-value pairs from Redis based on a match pattern and a given cursor.
-func (cli *Client) GetKeyValuePairs(ctx context.Context, matchPattern string, count int64, cursor uint64) (keyValuePairs map[string]string, newCursor uint64, err error) {
-	keyValuePairs = make(map[string]string)
+// If the client has a KeyPrefix, only keys within the namespace are matched and the prefix is removed from the returned keys.
+//
+// On cluster clients every master is scanned in turn and the returned cursor is an opaque token identifying both the
+// node and the position within it. A scan is complete when the returned cursor is 0. If the cluster topology changes
+// part way through a scan, ErrInvalidCursor is returned and the scan should be restarted from 0.
+//
+// In fail-open mode an error ends the scan, returning no pairs and a cursor of 0.
+func (cli *Client) GetKeyValuePairs(ctx context.Context, matchPattern string, count int64, cursor uint64, opts ...CallOption) (keyValuePairs map[string]string, newCursor uint64, err error) {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
 
-	// Get the list of keys matching the pattern, starting from the provided cursor
-	keys, newCursor, err := cli.redisClient.Scan(ctx, cursor, matchPattern, count).Result()
-	if err != nil {
-		return nil, 0, fmt.Errorf("error scanning keys: %w", err)
-	}
+	err = retry(ctx, func(ctx context.Context) error {
+		var node redis.UniversalClient
+		var keys []string
 
-	// If we have keys, get the values for those keys
-	if len(keys) > 0 {
-		values, err := cli.redisClient.MGet(ctx, keys...).Result()
+		node, keys, newCursor, err = cli.scanPage(ctx, matchPattern, count, cursor)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error fetching values for keys: %w", err)
+			return err
 		}
 
-		// Add the key-value pairs to the map
-		for i, key := range keys {
-			if i < len(values) {
-				if val, ok := values[i].(string); ok {
-					keyValuePairs[key] = val
-				}
-			}
-		}
+		keyValuePairs, err = cli.getValues(ctx, node, keys)
+		return err
+	})
+
+	if cli.suppressRead(ctx, matchPattern, err) {
+		return map[string]string{}, 0, nil
+	} else if err != nil {
+		return nil, 0, err
 	}
 
 	// Return the results along with the new cursor
 	return keyValuePairs, newCursor, nil
 }
 
-// GetTotalKeys returns the total number of keys in the Redis database.
+// GetTotalKeys returns the total number of keys in the Redis database, summed across every master of a cluster client.
+// If the client has a KeyPrefix, only the keys within the namespace are counted using CountKeys.
 func (cli *Client) GetTotalKeys(ctx context.Context) (int64, error) {
-	count, err := cli.redisClient.DBSize(ctx).Result()
+	if cli.keyPrefix != "" {
+		count, err := cli.CountKeys(ctx, "*")
+		if err != nil {
+			return 0, fmt.Errorf("error getting total number of keys: %w", err)
+		}
+		return count, nil
+	}
+
+	var total atomic.Int64
+
+	err := cli.forEachNode(ctx, true, func(ctx context.Context, node redis.UniversalClient) error {
+		count, err := node.DBSize(ctx).Result()
+		total.Add(count)
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("error getting total number of keys: %w", err)
 	}
-	return count, nil
+	return total.Load(), nil
 }
 
-// SetValue sets a key-value pair in Redis with an optional expiration time.
-func (cli *Client) SetValue(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	err := cli.redisClient.Set(ctx, key, value, expiration).Err()
+// SetValue sets a key-value pair in Redis with an optional expiration time. In fail-open mode a failed write is
+// dropped, or buffered and retried if ClientConfig.FailOpen has a WriteBufferSize, and nil is returned.
+func (cli *Client) SetValue(ctx context.Context, key string, value interface{}, expiration time.Duration, opts ...CallOption) error {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	err := retry(ctx, func(ctx context.Context) error {
+		return cli.setValue(ctx, key, value, expiration)
+	})
+	return cli.suppressWrite(ctx, key, err, func(ctx context.Context) error {
+		return cli.setValue(ctx, key, value, expiration)
+	})
+}
+
+// setValue writes the value of key to redis
+func (cli *Client) setValue(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	value, err := cli.encodeValue(ctx, cli.key(key), value)
+	if err != nil {
+		return fmt.Errorf("error encoding value for key %s: %w", key, err)
+	}
+
+	err = cli.redisClient.Set(ctx, cli.key(key), value, expiration).Err()
 	if err != nil {
 		// Wrap and return the error from Redis
 		return fmt.Errorf("failed to set value in Redis: %w", err)
@@ -143,10 +372,27 @@ func (cli *Client) SetValue(ctx context.Context, key string, value interface{},
 	return nil
 }
 
-// DeleteValue deletes a key-value pair from Redis
-func (cli *Client) DeleteValue(ctx context.Context, key string) error {
+// DeleteValue deletes a key-value pair from Redis. In fail-open mode a failed delete is dropped, or buffered and
+// retried, as with SetValue.
+func (cli *Client) DeleteValue(ctx context.Context, key string, opts ...CallOption) error {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	err := retry(ctx, func(ctx context.Context) error {
+		return cli.deleteValue(ctx, key)
+	})
+	return cli.suppressWrite(ctx, key, err, func(ctx context.Context) error {
+		if err := cli.deleteValue(ctx, key); err != nil && !errors.Is(err, ErrKeyNotFound) {
+			return err
+		}
+		return nil
+	})
+}
+
+// deleteValue deletes key from redis
+func (cli *Client) deleteValue(ctx context.Context, key string) error {
 	// Call the Del method to delete the key
-	result, err := cli.redisClient.Del(ctx, key).Result()
+	result, err := cli.redisClient.Del(ctx, cli.key(key)).Result()
 	if err != nil {
 		return fmt.Errorf("failed to delete key from Redis: %w", err)
 	}
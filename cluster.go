@@ -0,0 +1,241 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// clusterSlots is the number of hash slots in a redis cluster
+const clusterSlots = 16384
+
+// hashSlot returns the cluster hash slot of key. If the key contains a non-empty hash tag, e.g. "{user1}:profile",
+// only the tag is hashed so that related keys can be placed in the same slot.
+func hashSlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+
+	return int(crc16(key) % clusterSlots)
+}
+
+// crc16 implements the CRC16-CCITT (XMODEM) checksum used by redis cluster
+func crc16(key string) uint16 {
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// forEachNode runs fn concurrently against every master, and every replica unless mastersOnly is set, of a cluster
+// client. For other clients fn is run against the client itself.
+func (cli *Client) forEachNode(ctx context.Context, mastersOnly bool, fn func(ctx context.Context, node redis.UniversalClient) error) error {
+	cluster, isCluster := cli.redisClient.(*redis.ClusterClient)
+	if !isCluster {
+		return fn(ctx, cli.redisClient)
+	}
+
+	nodeFn := func(ctx context.Context, node *redis.Client) error {
+		return fn(ctx, node)
+	}
+
+	if mastersOnly {
+		return cluster.ForEachMaster(ctx, nodeFn)
+	}
+
+	return cluster.ForEachShard(ctx, nodeFn)
+}
+
+// A cluster cursor holds, from the most significant bits, a tag of the cluster's masters, the index of the master being
+// scanned plus one and the SCAN cursor within that master
+const (
+	// clusterCursorNodeShift is the bit offset of the master's index within a cluster cursor
+	clusterCursorNodeShift = 40
+	// clusterCursorNodeMask masks the position within a node from a cluster cursor
+	clusterCursorNodeMask = 1<<clusterCursorNodeShift - 1
+	// clusterCursorMastersShift is the bit offset of the masters tag within a cluster cursor
+	clusterCursorMastersShift = 52
+	// clusterCursorFieldMask masks the index or masters tag of a cluster cursor once shifted
+	clusterCursorFieldMask = 1<<(clusterCursorMastersShift-clusterCursorNodeShift) - 1
+)
+
+// scanClusterPage scans one page of the master identified by cursor, returning the node scanned, the keys found and
+// the cursor for the next page
+func (cli *Client) scanClusterPage(ctx context.Context, cluster *redis.ClusterClient, matchPattern string, count int64, cursor uint64) (redis.UniversalClient, []string, uint64, error) {
+	masters, err := clusterMasters(ctx, cluster)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("error listing cluster masters: %w", err)
+	}
+
+	index, nodeCursor, err := decodeClusterCursor(cursor, masters)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	node := masters[index]
+
+	keys, nextNodeCursor, err := node.Scan(ctx, nodeCursor, cli.matchPattern(matchPattern), count).Result()
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("error scanning keys on node %s: %w", node.Options().Addr, err)
+	}
+
+	var newCursor uint64
+	switch {
+	case nextNodeCursor != 0:
+		newCursor, err = encodeClusterCursor(masters, index, nextNodeCursor)
+	case index+1 < len(masters):
+		newCursor, err = encodeClusterCursor(masters, index+1, 0)
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return node, keys, newCursor, nil
+}
+
+// getValuesBySlot fetches the string values of keys from node, with one MGET per hash slot as multi-key commands
+// cannot span slots even when they are served by the same node
+func (cli *Client) getValuesBySlot(ctx context.Context, node redis.UniversalClient, keys []string) (map[string]string, error) {
+	keyValuePairs := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return keyValuePairs, nil
+	}
+
+	slotKeys := make(map[int][]string)
+	for _, key := range keys {
+		slot := hashSlot(key)
+		slotKeys[slot] = append(slotKeys[slot], key)
+	}
+
+	cmds := make(map[*redis.SliceCmd][]string, len(slotKeys))
+	_, err := node.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, keys := range slotKeys {
+			cmds[pipe.MGet(ctx, keys...)] = keys
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching values for keys: %w", err)
+	}
+
+	for cmd, keys := range cmds {
+		for i, val := range cmd.Val() {
+			if val, ok := val.(string); ok && i < len(keys) {
+				if val, err = cli.decodeValue(ctx, keys[i], val); err != nil {
+					return nil, fmt.Errorf("error decoding value for key %s: %w", cli.stripKey(keys[i]), err)
+				}
+				keyValuePairs[cli.stripKey(keys[i])] = val
+			}
+		}
+	}
+
+	return keyValuePairs, nil
+}
+
+// clusterMasters returns the master nodes of a cluster client, ordered by address so that they are visited in the
+// same order by every page of a scan
+func clusterMasters(ctx context.Context, cluster *redis.ClusterClient) ([]*redis.Client, error) {
+	var (
+		mu      sync.Mutex
+		masters []*redis.Client
+	)
+
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		mu.Lock()
+		defer mu.Unlock()
+		masters = append(masters, node)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(masters) == 0 {
+		return nil, errors.New("cluster has no masters")
+	}
+
+	sort.Slice(masters, func(i, j int) bool {
+		return masters[i].Options().Addr < masters[j].Options().Addr
+	})
+
+	return masters, nil
+}
+
+// mastersTag identifies the set of masters within a cluster cursor, so that a scan is not resumed on the wrong master
+// if the cluster's topology changes part way through it. One in 4096 changes leaves the tag the same by chance, in which
+// case the scan resumes on the master now at the same index.
+func mastersTag(masters []*redis.Client) uint64 {
+	addrs := make([]string, len(masters))
+	for i, node := range masters {
+		addrs[i] = node.Options().Addr
+	}
+
+	return uint64(crc16(strings.Join(addrs, ","))) & clusterCursorFieldMask
+}
+
+// encodeClusterCursor combines the tag of masters and the index of the master being scanned with a SCAN cursor returned
+// by it. The index is stored plus one so that the cursor of a master other than the first is never mistaken for the
+// end of a scan.
+func encodeClusterCursor(masters []*redis.Client, index int, nodeCursor uint64) (uint64, error) {
+	if index+1 > clusterCursorFieldMask {
+		return 0, errors.New("cluster has too many masters to encode a scan cursor, use ScanKeys instead")
+	}
+
+	if nodeCursor > clusterCursorNodeMask {
+		return 0, fmt.Errorf("scan cursor %d returned by node %s is too large to encode", nodeCursor, masters[index].Options().Addr)
+	}
+
+	return mastersTag(masters)<<clusterCursorMastersShift | uint64(index+1)<<clusterCursorNodeShift | nodeCursor, nil
+}
+
+// decodeClusterCursor returns the index within masters of the node identified by cursor and the SCAN cursor to
+// resume that node from. ErrInvalidCursor is returned if the cursor was not returned by a scan of the same masters.
+func decodeClusterCursor(cursor uint64, masters []*redis.Client) (int, uint64, error) {
+	if cursor == 0 {
+		return 0, 0, nil
+	}
+
+	index := int(cursor>>clusterCursorNodeShift&clusterCursorFieldMask) - 1
+	if cursor>>clusterCursorMastersShift != mastersTag(masters) || index < 0 || index >= len(masters) {
+		return 0, 0, fmt.Errorf("%w: %d", ErrInvalidCursor, cursor)
+	}
+
+	return index, cursor & clusterCursorNodeMask, nil
+}
+
+// scanNodes returns the nodes to visit, in order, to scan the whole keyspace: every master of a cluster client, or
+// the client itself otherwise
+func (cli *Client) scanNodes(ctx context.Context) ([]redis.UniversalClient, error) {
+	cluster, isCluster := cli.redisClient.(*redis.ClusterClient)
+	if !isCluster {
+		return []redis.UniversalClient{cli.redisClient}, nil
+	}
+
+	masters, err := clusterMasters(ctx, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster masters: %w", err)
+	}
+
+	nodes := make([]redis.UniversalClient, len(masters))
+	for i, master := range masters {
+		nodes[i] = master
+	}
+
+	return nodes, nil
+}
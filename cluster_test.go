@@ -0,0 +1,234 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHashSlot(t *testing.T) {
+	Convey("When the hash slot of a key is calculated", t, func() {
+		Convey("Then it matches the slot redis cluster assigns", func() {
+			So(crc16("123456789"), ShouldEqual, 0x31C3)
+			So(hashSlot("foo"), ShouldEqual, 12182)
+			So(hashSlot("somekey"), ShouldEqual, 11058)
+			So(hashSlot("hello"), ShouldEqual, 866)
+		})
+
+		Convey("Then keys sharing a hash tag share a slot", func() {
+			So(hashSlot("{user1000}.following"), ShouldEqual, hashSlot("user1000"))
+			So(hashSlot("{user1000}.followers"), ShouldEqual, hashSlot("user1000"))
+		})
+
+		Convey("Then an empty hash tag is ignored", func() {
+			So(hashSlot("foo{}{bar}"), ShouldEqual, int(crc16("foo{}{bar}")%clusterSlots))
+		})
+	})
+}
+
+// newStubbedClusterClient returns a cluster client with two masters, each of whose commands are answered by respond
+func newStubbedClusterClient(respond func(addr string, cmd redis.Cmder)) *redis.ClusterClient {
+	return newStubbedClusterClientOf([]string{"node-b:6379", "node-a:6379"}, respond)
+}
+
+// newStubbedClusterClientOf returns a cluster client whose slots are split evenly between masters at addrs, with
+// commands answered by respond
+func newStubbedClusterClientOf(addrs []string, respond func(addr string, cmd redis.Cmder)) *redis.ClusterClient {
+	return redis.NewClusterClient(&redis.ClusterOptions{
+		ClusterSlots: func(ctx context.Context) ([]redis.ClusterSlot, error) {
+			slots := make([]redis.ClusterSlot, len(addrs))
+			for i, addr := range addrs {
+				slots[i] = redis.ClusterSlot{
+					Start: i * clusterSlots / len(addrs),
+					End:   (i+1)*clusterSlots/len(addrs) - 1,
+					Nodes: []redis.ClusterNode{{Addr: addr}},
+				}
+			}
+			return slots, nil
+		},
+		NewClient: func(opt *redis.Options) *redis.Client {
+			client := redis.NewClient(opt)
+			client.AddHook(&stubHook{respond: func(cmd redis.Cmder) { respond(opt.Addr, cmd) }})
+			return client
+		},
+	})
+}
+
+// newUnreachableClusterClient returns a cluster client with two masters that cannot be connected to
+func newUnreachableClusterClient() *redis.ClusterClient {
+	return redis.NewClusterClient(&redis.ClusterOptions{
+		ClusterSlots: func(ctx context.Context) ([]redis.ClusterSlot, error) {
+			return []redis.ClusterSlot{
+				{Start: 0, End: 8191, Nodes: []redis.ClusterNode{{Addr: "node-b:6379"}}},
+				{Start: 8192, End: clusterSlots - 1, Nodes: []redis.ClusterNode{{Addr: "node-a:6379"}}},
+			}, nil
+		},
+		MaxRedirects: -1,
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+		},
+	})
+}
+
+func TestClient_ClusterNodeHooks(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a cluster client whose masters cannot be reached", t, func() {
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, newUnreachableClusterClient())
+		defer client.Close(ctx)
+
+		Convey("When keys are scanned, sending commands to the masters directly", func() {
+			_, _, err := client.GetKeyValuePairs(ctx, "key*", 10, 0)
+
+			Convey("Then the error is classified", func() {
+				So(err, ShouldWrap, ErrConnection)
+			})
+		})
+	})
+	Convey("Given a cluster client with a circuit breaker whose masters cannot be reached", t, func() {
+		client := NewClientWithCustomClient(ctx, &ClientConfig{
+			CircuitBreaker: &CircuitBreakerConfig{MinRequests: 2, FailureRate: 1},
+		}, newUnreachableClusterClient())
+		defer client.Close(ctx)
+
+		Convey("When a value is read through the cluster client", func() {
+			_, err := client.GetValue(ctx, "key")
+
+			Convey("Then the failure is counted once", func() {
+				So(err, ShouldWrap, ErrConnection)
+				So(client.breaker.requests, ShouldEqual, 1)
+				So(client.breaker.failures, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When keys are scanned until the circuit opens", func() {
+			_, _, err := client.GetKeyValuePairs(ctx, "key*", 10, 0)
+			So(err, ShouldWrap, ErrConnection)
+			_, _, err = client.GetKeyValuePairs(ctx, "key*", 10, 0)
+			So(err, ShouldWrap, ErrConnection)
+
+			_, _, err = client.GetKeyValuePairs(ctx, "key*", 10, 0)
+
+			Convey("Then the commands sent to the masters directly fail fast", func() {
+				So(client.CircuitState(), ShouldEqual, CircuitOpen)
+				So(err, ShouldWrap, ErrCircuitOpen)
+			})
+		})
+	})
+}
+
+func TestClient_GetKeyValuePairsCluster(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a cluster client with keys on two masters", t, func() {
+		var scans []string
+
+		clusterClient := newStubbedClusterClient(func(addr string, cmd redis.Cmder) {
+			switch cmd := cmd.(type) {
+			case *redis.ScanCmd:
+				cursor := cmd.Args()[1].(uint64)
+				scans = append(scans, addr)
+				switch {
+				case addr == "node-a:6379" && cursor == 0:
+					cmd.SetVal([]string{"key1", "key2"}, 3)
+				case addr == "node-a:6379":
+					cmd.SetVal([]string{"key3"}, 0)
+				default:
+					cmd.SetVal([]string{"key4"}, 0)
+				}
+			case *redis.SliceCmd:
+				vals := make([]interface{}, len(cmd.Args())-1)
+				for i, key := range cmd.Args()[1:] {
+					vals[i] = "val_for_" + key.(string)
+				}
+				cmd.SetVal(vals)
+			}
+		})
+		client := &Client{redisClient: clusterClient}
+
+		Convey("When every page is requested until the cursor is 0", func() {
+			results := make(map[string]string)
+			var cursor uint64
+			var pages int
+			for {
+				page, next, err := client.GetKeyValuePairs(ctx, "key*", 10, cursor)
+				So(err, ShouldBeNil)
+				for key, val := range page {
+					results[key] = val
+				}
+				pages++
+				if next == 0 {
+					break
+				}
+				cursor = next
+			}
+
+			Convey("Then every master is walked in address order and all values are returned", func() {
+				So(pages, ShouldEqual, 3)
+				So(strings.Join(scans, ","), ShouldEqual, "node-a:6379,node-a:6379,node-b:6379")
+				So(results, ShouldResemble, map[string]string{
+					"key1": "val_for_key1",
+					"key2": "val_for_key2",
+					"key3": "val_for_key3",
+					"key4": "val_for_key4",
+				})
+			})
+		})
+
+		Convey("When a cursor that does not belong to any master is used", func() {
+			_, _, err := client.GetKeyValuePairs(ctx, "key*", 10, 3<<clusterCursorNodeShift|5)
+
+			Convey("Then ErrInvalidCursor is returned", func() {
+				So(err, ShouldWrap, ErrInvalidCursor)
+			})
+		})
+
+		Convey("When a scan is resumed after a master has been replaced", func() {
+			_, cursor, err := client.GetKeyValuePairs(ctx, "key*", 10, 0)
+			So(err, ShouldBeNil)
+			So(cursor, ShouldNotEqual, 0)
+
+			replaced := &Client{redisClient: newStubbedClusterClientOf([]string{"node-a:6379", "node-c:6379"},
+				func(addr string, cmd redis.Cmder) {
+					scans = append(scans, addr)
+				})}
+			_, _, err = replaced.GetKeyValuePairs(ctx, "key*", 10, cursor)
+
+			Convey("Then ErrInvalidCursor is returned without scanning", func() {
+				So(err, ShouldWrap, ErrInvalidCursor)
+				So(scans, ShouldResemble, []string{"node-a:6379"})
+			})
+		})
+	})
+
+	Convey("Given a cluster client with two masters whose addresses have the same crc16 checksum", t, func() {
+		addrs := []string{"node-2885:6379", "node-8660:6379"}
+		So(crc16(addrs[0]), ShouldEqual, crc16(addrs[1]))
+
+		var scans []string
+		client := &Client{redisClient: newStubbedClusterClientOf(addrs, func(addr string, cmd redis.Cmder) {
+			if cmd, isScan := cmd.(*redis.ScanCmd); isScan {
+				scans = append(scans, addr)
+				cmd.SetVal([]string{}, 0)
+			}
+		})}
+
+		Convey("When every page is requested until the cursor is 0", func() {
+			_, cursor, err := client.GetKeyValuePairs(ctx, "key*", 10, 0)
+			So(err, ShouldBeNil)
+			So(cursor, ShouldNotEqual, 0)
+			_, cursor, err = client.GetKeyValuePairs(ctx, "key*", 10, cursor)
+
+			Convey("Then both masters are scanned", func() {
+				So(err, ShouldBeNil)
+				So(cursor, ShouldEqual, 0)
+				So(scans, ShouldResemble, addrs)
+			})
+		})
+	})
+}
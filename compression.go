@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is an algorithm used to compress values written by the client.
+type Compression string
+
+// Compression algorithms
+const (
+	CompressionNone   Compression = ""
+	CompressionGzip   Compression = "gzip"
+	CompressionZstd   Compression = "zstd"
+	CompressionSnappy Compression = "snappy"
+)
+
+// defaultCompressionThreshold is the default minimum size in bytes of a value to compress
+const defaultCompressionThreshold = 1024
+
+// zstdEncoder and zstdDecoder are shared as they are expensive to create and safe for concurrent use
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil)
+	})
+)
+
+// Validate will validate that the compression algorithm is supported
+func (c Compression) Validate() error {
+	switch c {
+	case CompressionNone, CompressionGzip, CompressionZstd, CompressionSnappy:
+		return nil
+	}
+
+	return fmt.Errorf("unsupported compression %q", c)
+}
+
+// header returns the byte identifying the algorithm in a value's header
+func (c Compression) header() byte {
+	switch c {
+	case CompressionGzip:
+		return headerGzip
+	case CompressionZstd:
+		return headerZstd
+	case CompressionSnappy:
+		return headerSnappy
+	}
+
+	return 0
+}
+
+// compress returns data compressed with the algorithm, preceded by its header
+func (c Compression) compress(data []byte) ([]byte, error) {
+	out := valueHeader(c.header())
+
+	switch c {
+	case CompressionGzip:
+		buf := bytes.NewBuffer(out)
+		w := gzip.NewWriter(buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		encoder, err := zstdEncoder()
+		if err != nil {
+			return nil, err
+		}
+		return encoder.EncodeAll(data, out), nil
+	case CompressionSnappy:
+		return append(out, snappy.Encode(nil, data)...), nil
+	}
+
+	return nil, fmt.Errorf("unsupported compression %q", c)
+}
+
+// decompress returns the data of a value compressed with the algorithm identified by header, without its header
+func decompress(header byte, data []byte) ([]byte, error) {
+	switch header {
+	case headerGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	case headerZstd:
+		decoder, err := zstdDecoder()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.DecodeAll(data, nil)
+	case headerSnappy:
+		return snappy.Decode(nil, data)
+	}
+
+	return nil, fmt.Errorf("unknown compression header %#x", header)
+}
@@ -0,0 +1,177 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/fake"
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_Compression(t *testing.T) {
+	ctx := context.Background()
+	large := strings.Repeat("navigation-item;", 200)
+
+	for _, compression := range []Compression{CompressionGzip, CompressionZstd, CompressionSnappy} {
+		Convey("Given a mocked Redis client and a client compressing values with "+string(compression), t, func() {
+			store := map[string]string{"plain": large}
+
+			mockRedisClient := &mocks.GoRedisClientMock{
+				SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+					switch v := value.(type) {
+					case string:
+						store[key] = v
+					case []byte:
+						store[key] = string(v)
+					}
+					return redis.NewStatusCmd(ctx, "set", key)
+				},
+				GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+					cmd := redis.NewStringCmd(ctx, "get", key)
+					if val, ok := store[key]; ok {
+						cmd.SetVal(val)
+					} else {
+						cmd.SetErr(redis.Nil)
+					}
+					return cmd
+				},
+			}
+
+			client := &Client{redisClient: mockRedisClient, compression: compression}
+
+			Convey("When a value above the threshold is set and read back", func() {
+				So(client.SetValue(ctx, "nav", large, 0), ShouldBeNil)
+				val, err := client.GetValue(ctx, "nav")
+
+				Convey("Then it is stored compressed with a header and read back unchanged", func() {
+					So(err, ShouldBeNil)
+					So(val, ShouldEqual, large)
+					So(len(store["nav"]), ShouldBeLessThan, len(large))
+					So(store["nav"], ShouldStartWith, string(valueHeader(compression.header())))
+				})
+			})
+
+			Convey("When a value below the threshold is set", func() {
+				So(client.SetValue(ctx, "small", "short", 0), ShouldBeNil)
+
+				Convey("Then it is stored as it is", func() {
+					So(store["small"], ShouldEqual, "short")
+				})
+			})
+
+			Convey("When a plain value written without compression is read", func() {
+				val, err := client.GetValue(ctx, "plain")
+
+				Convey("Then it is returned unchanged", func() {
+					So(err, ShouldBeNil)
+					So(val, ShouldEqual, large)
+				})
+			})
+		})
+	}
+
+	Convey("Given a client compressing values with a low threshold", t, func() {
+		client := &Client{compression: CompressionGzip, compressionThreshold: 4}
+
+		Convey("When a value that does not compress is encoded", func() {
+			val, err := client.encodeValue(ctx, "key", "abcdefgh")
+
+			Convey("Then the value is kept as it is", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "abcdefgh")
+			})
+		})
+
+		Convey("When a value that is not a string is encoded", func() {
+			val, err := client.encodeValue(ctx, "key", 12345)
+
+			Convey("Then the value is kept as it is", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, 12345)
+			})
+		})
+	})
+
+	Convey("Given a client compressing values", t, func() {
+		client := &Client{compression: CompressionGzip}
+
+		Convey("When a corrupt compressed value is decoded", func() {
+			_, err := client.decodeValue(ctx, "key", string(valueHeader(headerGzip))+"x")
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a binary value written without compression starts with the first byte of a header", func() {
+			val, err := client.decodeValue(ctx, "key", string([]byte{valueMarker[0], headerZstd, 1, 2}))
+
+			Convey("Then it is returned unchanged", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, string([]byte{valueMarker[0], headerZstd, 1, 2}))
+			})
+		})
+	})
+
+	Convey("Given a client that neither compresses nor encrypts values", t, func() {
+		srv := fake.NewServer()
+		defer srv.Close()
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, srv.NewClient())
+		defer client.Close(ctx)
+
+		Convey("When a binary value starting with a byte that cannot begin valid UTF-8 is set and read back", func() {
+			value := []byte{0xF6, 1, 2}
+			So(client.SetValue(ctx, "binary", value, 0), ShouldBeNil)
+			val, err := client.GetValue(ctx, "binary")
+
+			Convey("Then it is returned unchanged", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, string(value))
+			})
+		})
+
+		Convey("When a value written by a client compressing values is read", func() {
+			compressing := NewClientWithCustomClient(ctx, &ClientConfig{Compression: CompressionGzip, CompressionThreshold: 1},
+				srv.NewClient())
+			defer compressing.Close(ctx)
+
+			value := strings.Repeat("compressible ", 100)
+			So(compressing.SetValue(ctx, "compressed", value, 0), ShouldBeNil)
+			raw := srv.NewClient()
+			defer raw.Close()
+			stored, err := raw.Get(ctx, "compressed").Result()
+			So(err, ShouldBeNil)
+			So(stored, ShouldStartWith, valueMarker)
+
+			val, err := client.GetValue(ctx, "compressed")
+
+			Convey("Then it is decompressed", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, value)
+			})
+		})
+
+		Convey("When a value written by a client encrypting values is read", func() {
+			provider, err := NewStaticKeyProvider("key", map[string][]byte{"key": bytes.Repeat([]byte{1}, 32)})
+			So(err, ShouldBeNil)
+			encrypting := NewClientWithCustomClient(ctx, &ClientConfig{KeyProvider: provider}, srv.NewClient())
+			defer encrypting.Close(ctx)
+
+			So(encrypting.SetValue(ctx, "encrypted", "secret", 0), ShouldBeNil)
+
+			val, err := client.GetValue(ctx, "encrypted")
+
+			Convey("Then an error is returned rather than the encrypted value", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "no key provider")
+				So(val, ShouldBeEmpty)
+			})
+		})
+	})
+}
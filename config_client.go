@@ -16,6 +16,45 @@ type ClientConfig struct {
 	Region      string
 	Service     string
 	Username    string
+	// KeyPrefix namespaces every key used by the client, so that services can share a cluster without collisions.
+	// The prefix is added to keys and match patterns sent to redis and removed from keys returned by the client.
+	KeyPrefix string
+	// PipelineBatchSize is the maximum number of commands sent in each pipeline by bulk operations such as SetValues.
+	// Defaults to 100.
+	PipelineBatchSize int
+	// ScanCount is the COUNT hint sent with SCAN when counting or iterating over keys. Defaults to 1000.
+	ScanCount int64
+	// TransactionMaxRetries is the number of times Transaction retries when its watched keys are modified. Defaults to 5.
+	TransactionMaxRetries int
+	// Compression compresses string values of at least CompressionThreshold bytes written by the client. Compressed
+	// values are marked with a header and decompressed when read by any client, whatever its Compression, so plain and
+	// compressed values can coexist.
+	Compression Compression
+	// CompressionThreshold is the minimum size in bytes of a value to compress. Defaults to 1024.
+	CompressionThreshold int
+	// KeyProvider enables encryption of values written by the client with AES-GCM, using keys from the provider. Values
+	// are compressed before they are encrypted. Encrypted values can only be read by clients with a KeyProvider holding
+	// the key they were encrypted with.
+	KeyProvider KeyProvider
+	// CircuitBreaker fails commands fast with ErrCircuitOpen while redis is unavailable, rather than waiting for each
+	// command to time out.
+	CircuitBreaker *CircuitBreakerConfig
+	// FailOpen treats redis failures as cache misses: failed reads with GetValue and GetKeyValuePairs return
+	// ErrKeyNotFound or no pairs, and failed writes with SetValue and DeleteValue are dropped or buffered for retry.
+	// Suppressed errors are logged and counted in FailOpenStats. WithFailOpen overrides it for a single call.
+	FailOpen *FailOpenConfig
+	// Tracking enables server-assisted client-side caching of values read with GetValue. Values are only cached for
+	// go-redis Client and ClusterClient connections, and every value is read from redis if the server does not
+	// support tracking.
+	Tracking *TrackingConfig
+	// ReaderAddress is the address of a read only endpoint, such as an ElastiCache reader endpoint. If set, NewClient
+	// sends reads made with GetValue and GetKeyValuePairs to it and writes to Address. WithReplicaRead(false) reads a
+	// single call from Address, for example to read the caller's own writes. Not used by cluster clients.
+	ReaderAddress string
+	// ReadOnly lets cluster clients read from replicas. WithReplicaRead(false) reads a single call from the key's master.
+	ReadOnly bool
+	// RouteByLatency sends cluster reads to the master or replica with the lowest latency. It implies ReadOnly.
+	RouteByLatency bool
 	// go-redis config overrides
 	Address   string
 	Database  *int
@@ -91,5 +130,43 @@ func (c *ClientConfig) Validate() error {
 	if c.Username != "" && c.Region == "" {
 		return fmt.Errorf("region must be provided when username is set")
 	}
+
+	if c.PipelineBatchSize < 0 {
+		return fmt.Errorf("pipeline batch size must not be negative")
+	}
+
+	if c.ScanCount < 0 {
+		return fmt.Errorf("scan count must not be negative")
+	}
+
+	if c.TransactionMaxRetries < 0 {
+		return fmt.Errorf("transaction max retries must not be negative")
+	}
+
+	if err := c.Compression.Validate(); err != nil {
+		return err
+	}
+
+	if c.CompressionThreshold < 0 {
+		return fmt.Errorf("compression threshold must not be negative")
+	}
+
+	if c.CircuitBreaker != nil {
+		if err := c.CircuitBreaker.Validate(); err != nil {
+			return fmt.Errorf("invalid circuit breaker config: %w", err)
+		}
+	}
+
+	if c.FailOpen != nil {
+		if err := c.FailOpen.Validate(); err != nil {
+			return fmt.Errorf("invalid fail open config: %w", err)
+		}
+	}
+
+	if c.Tracking != nil {
+		if err := c.Tracking.Validate(); err != nil {
+			return fmt.Errorf("invalid tracking config: %w", err)
+		}
+	}
 	return nil
 }
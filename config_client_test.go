@@ -77,4 +77,64 @@ func TestGetConfig(t *testing.T) {
 			So(err, ShouldNotBeNil)
 		})
 	})
+
+	Convey("When a configuration is requested with a negative pipeline batch size", t, func() {
+		cfg := ClientConfig{
+			PipelineBatchSize: -1,
+		}
+		ctx := context.Background()
+		_, err := cfg.Get(ctx)
+
+		Convey("Then an error is returned indicating the invalid configuration", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("When a configuration is requested with an unsupported compression", t, func() {
+		cfg := ClientConfig{
+			Compression: "lz4",
+		}
+		ctx := context.Background()
+		_, err := cfg.Get(ctx)
+
+		Convey("Then an error is returned indicating the invalid configuration", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("When a configuration is requested with an invalid circuit breaker config", t, func() {
+		cfg := ClientConfig{
+			CircuitBreaker: &CircuitBreakerConfig{FailureRate: 2},
+		}
+		ctx := context.Background()
+		_, err := cfg.Get(ctx)
+
+		Convey("Then an error is returned indicating the invalid configuration", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("When a configuration is requested with an invalid fail open config", t, func() {
+		cfg := ClientConfig{
+			FailOpen: &FailOpenConfig{WriteBufferSize: -1},
+		}
+		ctx := context.Background()
+		_, err := cfg.Get(ctx)
+
+		Convey("Then an error is returned indicating the invalid configuration", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("When a configuration is requested with an invalid tracking config", t, func() {
+		cfg := ClientConfig{
+			Tracking: &TrackingConfig{Prefixes: []string{"nav:"}},
+		}
+		ctx := context.Background()
+		_, err := cfg.Get(ctx)
+
+		Convey("Then an error is returned indicating the invalid configuration", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
 }
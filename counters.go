@@ -0,0 +1,196 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// incrementScript increments a counter and sets its expiry if it does not already have one, so that the expiry is
+// only set by the first increment and cannot be lost if the client fails between the two commands
+var incrementScript = redis.NewScript(`
+local value = redis.call(ARGV[1], KEYS[1], ARGV[2])
+if redis.call('PTTL', KEYS[1]) == -1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+end
+return value
+`)
+
+// Incr increments the integer value of key by one and returns the new value. A missing key is treated as zero.
+// If expiration is greater than zero it is set atomically on the key when the key does not already have an expiry,
+// i.e. on the first increment.
+func (cli *Client) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	return cli.IncrBy(ctx, key, 1, expiration)
+}
+
+// Decr decrements the integer value of key by one and returns the new value, setting expiration in the same way as Incr.
+func (cli *Client) Decr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	return cli.IncrBy(ctx, key, -1, expiration)
+}
+
+// IncrBy increments the integer value of key by the given amount and returns the new value, setting expiration in the
+// same way as Incr.
+func (cli *Client) IncrBy(ctx context.Context, key string, by int64, expiration time.Duration) (int64, error) {
+	var (
+		val int64
+		err error
+	)
+
+	if expiration > 0 {
+		val, err = incrementScript.Run(ctx, cli.redisClient, []string{cli.key(key)}, "INCRBY", by, expiration.Milliseconds()).Int64()
+	} else {
+		val, err = cli.redisClient.IncrBy(ctx, cli.key(key), by).Result()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error incrementing key %s: %w", key, err)
+	}
+
+	return val, nil
+}
+
+// IncrByFloat increments the floating point value of key by the given amount and returns the new value, setting
+// expiration in the same way as Incr.
+func (cli *Client) IncrByFloat(ctx context.Context, key string, by float64, expiration time.Duration) (float64, error) {
+	var (
+		val float64
+		err error
+	)
+
+	if expiration > 0 {
+		var reply string
+		reply, err = incrementScript.Run(ctx, cli.redisClient, []string{cli.key(key)}, "INCRBYFLOAT", by, expiration.Milliseconds()).Text()
+		if err == nil {
+			val, err = strconv.ParseFloat(reply, 64)
+		}
+	} else {
+		val, err = cli.redisClient.IncrByFloat(ctx, cli.key(key), by).Result()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error incrementing key %s: %w", key, err)
+	}
+
+	return val, nil
+}
+
+// WindowedCounterConfig configures how a WindowedCounter buckets its counts.
+type WindowedCounterConfig struct {
+	// BucketSize is the granularity counts are recorded at, e.g. a minute or an hour.
+	BucketSize time.Duration
+	// Retention is how long counts are kept for. Counts older than Retention are not included by Count.
+	Retention time.Duration
+}
+
+// WindowedCounter counts events in fixed time buckets, e.g. page views per minute or API calls per hour, and sums the
+// buckets across a time range.
+type WindowedCounter interface {
+	// Increment adds by to the count of the current bucket and returns the bucket's new count.
+	Increment(ctx context.Context, by int64) (int64, error)
+	// Count returns the sum of the buckets from the one containing from up to and including the one containing to.
+	Count(ctx context.Context, from, to time.Time) (int64, error)
+}
+
+// windowedCounter is the WindowedCounter returned by NewWindowedCounter. The buckets share a hash tag so they live in
+// the same cluster slot.
+type windowedCounter struct {
+	client *Client
+	name   string
+	config WindowedCounterConfig
+	now    func() time.Time
+}
+
+// NewWindowedCounter returns a WindowedCounter that stores its counts under keys derived from name.
+func (cli *Client) NewWindowedCounter(name string, config WindowedCounterConfig) (WindowedCounter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	return &windowedCounter{
+		client: cli,
+		name:   name,
+		config: config,
+		now:    time.Now,
+	}, nil
+}
+
+// Validate will validate that the windowed counter config describes usable buckets
+func (c *WindowedCounterConfig) Validate() error {
+	if c.BucketSize <= 0 {
+		return errors.New("bucket size must be greater than zero")
+	}
+
+	if c.Retention < c.BucketSize {
+		return errors.New("retention must not be smaller than bucket size")
+	}
+
+	return nil
+}
+
+// Increment adds by to the count of the current bucket and returns the bucket's new count.
+func (w *windowedCounter) Increment(ctx context.Context, by int64) (int64, error) {
+	key := w.bucketKey(w.now().Truncate(w.config.BucketSize))
+
+	// Buckets are kept until they have fully left the retention period
+	count, err := w.client.IncrBy(ctx, key, by, w.config.Retention+w.config.BucketSize)
+	if err != nil {
+		return 0, fmt.Errorf("error incrementing windowed counter %s: %w", w.name, err)
+	}
+
+	return count, nil
+}
+
+// Count returns the sum of the buckets from the one containing from up to and including the one containing to.
+// Buckets older than the counter's retention have expired and future buckets have not started, so both count as zero.
+func (w *windowedCounter) Count(ctx context.Context, from, to time.Time) (int64, error) {
+	if to.Before(from) {
+		return 0, errors.New("to must not be before from")
+	}
+
+	// Only buckets within the retention period, up to the current bucket, can hold counts
+	now := w.now()
+	oldest := now.Add(-w.config.Retention).Truncate(w.config.BucketSize)
+	if from.Before(oldest) {
+		from = oldest
+	}
+	if to.After(now) {
+		to = now
+	}
+
+	var keys []string
+	for bucket := from.Truncate(w.config.BucketSize); !bucket.After(to); bucket = bucket.Add(w.config.BucketSize) {
+		keys = append(keys, w.client.key(w.bucketKey(bucket)))
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	values, err := w.client.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error getting windowed counter %s: %w", w.name, err)
+	}
+
+	var total int64
+	for _, val := range values {
+		s, ok := val.(string)
+		if !ok {
+			continue
+		}
+
+		count, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing windowed counter %s: %w", w.name, err)
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+// bucketKey returns the key of the bucket starting at t, without the client's namespace
+func (w *windowedCounter) bucketKey(t time.Time) string {
+	return fmt.Sprintf("{%s}:%d", w.name, t.Unix())
+}
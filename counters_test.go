@@ -0,0 +1,171 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_Counters(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mocked Redis client", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			IncrByFunc: func(ctx context.Context, key string, value int64) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "incrby", key, value)
+				cmd.SetVal(10 + value)
+				return cmd
+			},
+			IncrByFloatFunc: func(ctx context.Context, key string, value float64) *redis.FloatCmd {
+				cmd := redis.NewFloatCmd(ctx, "incrbyfloat", key, value)
+				cmd.SetVal(1.5 + value)
+				return cmd
+			},
+			EvalShaFunc: func(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+				cmd := redis.NewCmd(ctx, "evalsha", sha1)
+				if args[0] == "INCRBYFLOAT" {
+					cmd.SetVal("2.25")
+				} else {
+					cmd.SetVal(int64(1))
+				}
+				return cmd
+			},
+		}
+
+		client := &Client{redisClient: mockRedisClient}
+
+		Convey("When counters are changed without an expiry", func() {
+			incremented, err := client.Incr(ctx, "views", 0)
+			So(err, ShouldBeNil)
+			decremented, err := client.Decr(ctx, "views", 0)
+			So(err, ShouldBeNil)
+			floated, err := client.IncrByFloat(ctx, "score", 0.5, 0)
+			So(err, ShouldBeNil)
+
+			Convey("Then the increment commands are used directly", func() {
+				So(incremented, ShouldEqual, 11)
+				So(decremented, ShouldEqual, 9)
+				So(floated, ShouldEqual, 2)
+				So(mockRedisClient.IncrByCalls()[1].Value, ShouldEqual, -1)
+				So(mockRedisClient.EvalShaCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When counters are changed with an expiry", func() {
+			count, err := client.IncrBy(ctx, "quota", 1, time.Hour)
+			So(err, ShouldBeNil)
+			floated, err := client.IncrByFloat(ctx, "score", 0.75, time.Minute)
+			So(err, ShouldBeNil)
+
+			Convey("Then the increment and expiry are applied together by a script", func() {
+				So(count, ShouldEqual, 1)
+				So(floated, ShouldEqual, 2.25)
+				So(mockRedisClient.IncrByCalls(), ShouldBeEmpty)
+				So(mockRedisClient.EvalShaCalls()[0].Keys, ShouldResemble, []string{"quota"})
+				So(mockRedisClient.EvalShaCalls()[0].Args, ShouldResemble, []interface{}{"INCRBY", int64(1), int64(3600000)})
+			})
+		})
+	})
+}
+
+func TestWindowedCounter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	Convey("When a windowed counter is created with a retention smaller than its buckets", t, func() {
+		_, err := (&Client{}).NewWindowedCounter("views", WindowedCounterConfig{BucketSize: time.Hour, Retention: time.Minute})
+
+		Convey("Then a validation error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given an hourly windowed counter kept for three hours", t, func() {
+		counts := map[string]string{
+			"{views}:1735732800": "5",
+			"{views}:1735729200": "3",
+			"{views}:1735725600": "2",
+		}
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			EvalShaFunc: func(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+				cmd := redis.NewCmd(ctx, "evalsha", sha1)
+				cmd.SetVal(int64(6))
+				return cmd
+			},
+			MGetFunc: func(ctx context.Context, keys ...string) *redis.SliceCmd {
+				cmd := redis.NewSliceCmd(ctx, "mget")
+				vals := make([]interface{}, len(keys))
+				for i, key := range keys {
+					if count, ok := counts[key]; ok {
+						vals[i] = count
+					}
+				}
+				cmd.SetVal(vals)
+				return cmd
+			},
+		}
+
+		client := &Client{redisClient: mockRedisClient}
+
+		counter, err := client.NewWindowedCounter("views", WindowedCounterConfig{BucketSize: time.Hour, Retention: 3 * time.Hour})
+		So(err, ShouldBeNil)
+		counter.(*windowedCounter).now = func() time.Time { return now }
+
+		Convey("When the counter is incremented", func() {
+			count, err := counter.Increment(ctx, 1)
+
+			Convey("Then the current bucket is incremented and kept until it leaves the retention period", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 6)
+				So(mockRedisClient.EvalShaCalls()[0].Keys, ShouldResemble, []string{"{views}:1735732800"})
+				So(mockRedisClient.EvalShaCalls()[0].Args[2], ShouldEqual, (4 * time.Hour).Milliseconds())
+			})
+		})
+
+		Convey("When the count over the last two hours is requested", func() {
+			count, err := counter.Count(ctx, now.Add(-time.Hour), now)
+
+			Convey("Then the buckets in the range are summed", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 8)
+				So(mockRedisClient.MGetCalls()[0].Keys, ShouldResemble, []string{"{views}:1735729200", "{views}:1735732800"})
+			})
+		})
+
+		Convey("When the count from before the retention period is requested", func() {
+			count, err := counter.Count(ctx, now.Add(-24*time.Hour), now)
+
+			Convey("Then only buckets within the retention period are read", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 10)
+				So(mockRedisClient.MGetCalls()[0].Keys, ShouldHaveLength, 4)
+				So(mockRedisClient.MGetCalls()[0].Keys[0], ShouldEqual, "{views}:1735722000")
+			})
+		})
+
+		Convey("When the count up to a time in the future is requested", func() {
+			count, err := counter.Count(ctx, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+
+			Convey("Then only buckets up to the current one are read", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 8)
+				So(mockRedisClient.MGetCalls()[0].Keys, ShouldResemble, []string{"{views}:1735729200", "{views}:1735732800"})
+			})
+		})
+
+		Convey("When the count of a range entirely in the future is requested", func() {
+			count, err := counter.Count(ctx, now.Add(time.Hour), now.Add(2*time.Hour))
+
+			Convey("Then it is zero without reading any buckets", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+				So(mockRedisClient.MGetCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,306 @@
+package redis
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultDataKeyTTL = time.Hour
+
+// KeyProvider supplies the AES keys used to encrypt values written by the client. Every encrypted value records the ID
+// of the key it was encrypted with, so keys can be rotated by changing the encryption key while older keys remain
+// available for decryption.
+type KeyProvider interface {
+	// EncryptionKey returns the key used to encrypt new values and its ID. The key must be 16, 24 or 32 bytes long.
+	EncryptionKey(ctx context.Context) (id string, key []byte, err error)
+	// DecryptionKey returns the key with the given ID.
+	DecryptionKey(ctx context.Context, id string) ([]byte, error)
+}
+
+// StaticKeyProvider is a KeyProvider holding a fixed set of keys, for example loaded from a secret store.
+type StaticKeyProvider struct {
+	currentID string
+	keys      map[string][]byte
+}
+
+// NewStaticKeyProvider returns a KeyProvider encrypting values with the key currentID and decrypting them with any of
+// keys. To rotate keys, add a new key and make it current while keeping the previous keys until no values encrypted
+// with them remain.
+func NewStaticKeyProvider(currentID string, keys map[string][]byte) (*StaticKeyProvider, error) {
+	if _, ok := keys[currentID]; !ok {
+		return nil, fmt.Errorf("current key %q is not one of the keys", currentID)
+	}
+
+	for id, key := range keys {
+		if err := validateKeySize(key); err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", id, err)
+		}
+	}
+
+	return &StaticKeyProvider{currentID: currentID, keys: keys}, nil
+}
+
+// EncryptionKey returns the current key
+func (p *StaticKeyProvider) EncryptionKey(ctx context.Context) (id string, key []byte, err error) {
+	return p.currentID, p.keys[p.currentID], nil
+}
+
+// DecryptionKey returns the key with the given ID
+func (p *StaticKeyProvider) DecryptionKey(ctx context.Context, id string) ([]byte, error) {
+	key, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %q", id)
+	}
+
+	return key, nil
+}
+
+// KMS generates and decrypts data keys under a master key, as AWS KMS does with GenerateDataKey and Decrypt.
+type KMS interface {
+	// GenerateDataKey returns a new 32 byte data key, in plaintext and encrypted under the master key keyID.
+	GenerateDataKey(ctx context.Context, keyID string) (plaintext, ciphertext []byte, err error)
+	// Decrypt returns the plaintext of a data key encrypted by GenerateDataKey.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// KMSKeyProviderConfig configures a KMSKeyProvider.
+type KMSKeyProviderConfig struct {
+	// KeyID identifies the master key that data keys are encrypted under.
+	KeyID string
+	// DataKeyTTL is how long a data key is used to encrypt values before a new one is generated. Defaults to one hour.
+	DataKeyTTL time.Duration
+}
+
+// Validate will validate that the KMS key provider config is usable
+func (c *KMSKeyProviderConfig) Validate() error {
+	if c.KeyID == "" {
+		return errors.New("key id must be provided")
+	}
+
+	if c.DataKeyTTL < 0 {
+		return errors.New("data key ttl cannot be negative")
+	}
+
+	return nil
+}
+
+// KMSKeyProvider is a KeyProvider using envelope encryption. Values are encrypted with data keys generated by a KMS,
+// and the encrypted data key is stored as the value's key ID, so only holders of the master key can read the values.
+// Decrypted data keys are held in memory to avoid a KMS call for every value read.
+type KMSKeyProvider struct {
+	kms    KMS
+	config KMSKeyProviderConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	current  string
+	expires  time.Time
+	dataKeys map[string][]byte
+}
+
+// NewKMSKeyProvider returns a KeyProvider generating data keys with kms.
+func NewKMSKeyProvider(kms KMS, config KMSKeyProviderConfig) (*KMSKeyProvider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	if config.DataKeyTTL == 0 {
+		config.DataKeyTTL = defaultDataKeyTTL
+	}
+
+	return &KMSKeyProvider{
+		kms:      kms,
+		config:   config,
+		now:      time.Now,
+		dataKeys: make(map[string][]byte),
+	}, nil
+}
+
+// EncryptionKey returns the current data key, generating a new one if it has expired
+func (p *KMSKeyProvider) EncryptionKey(ctx context.Context) (id string, key []byte, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.current != "" && p.now().Before(p.expires) {
+		return p.current, p.dataKeys[p.current], nil
+	}
+
+	plaintext, ciphertext, err := p.kms.GenerateDataKey(ctx, p.config.KeyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating data key: %w", err)
+	}
+
+	p.current = string(ciphertext)
+	p.expires = p.now().Add(p.config.DataKeyTTL)
+	p.dataKeys[p.current] = plaintext
+
+	return p.current, plaintext, nil
+}
+
+// DecryptionKey returns the data key encrypted as id, decrypting it with the KMS the first time it is used
+func (p *KMSKeyProvider) DecryptionKey(ctx context.Context, id string) ([]byte, error) {
+	p.mu.Lock()
+	key, ok := p.dataKeys[id]
+	p.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+
+	key, err := p.kms.Decrypt(ctx, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting data key: %w", err)
+	}
+
+	p.mu.Lock()
+	p.dataKeys[id] = key
+	p.mu.Unlock()
+
+	return key, nil
+}
+
+// LocalKMS is a KMS holding its master key in memory. It stands in for AWS KMS in local development and tests, where
+// values must be encrypted in the same way without access to AWS.
+type LocalKMS struct {
+	keyID string
+	aead  cipher.AEAD
+}
+
+// NewLocalKMS returns a KMS with a single master key identified by keyID.
+func NewLocalKMS(keyID string, masterKey []byte) (*LocalKMS, error) {
+	aead, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid master key: %w", err)
+	}
+
+	return &LocalKMS{keyID: keyID, aead: aead}, nil
+}
+
+// GenerateDataKey returns a new random data key and the key encrypted under the master key
+func (k *LocalKMS) GenerateDataKey(ctx context.Context, keyID string) (plaintext, ciphertext []byte, err error) {
+	if keyID != k.keyID {
+		return nil, nil, fmt.Errorf("unknown master key %q", keyID)
+	}
+
+	plaintext = make([]byte, 32)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, nil, err
+	}
+
+	ciphertext, err = seal(k.aead, plaintext, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plaintext, ciphertext, nil
+}
+
+// Decrypt returns the plaintext of a data key encrypted under the master key
+func (k *LocalKMS) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	return open(k.aead, ciphertext, nil)
+}
+
+// encrypt returns the data of redisKey encrypted with the provider's current key, preceded by the encryption header and
+// the key's ID. The header, key ID and redisKey are authenticated along with the data, so that a value cannot be
+// moved to another key without failing to decrypt.
+func encrypt(ctx context.Context, provider KeyProvider, redisKey string, data []byte) ([]byte, error) {
+	id, key, err := provider.EncryptionKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting encryption key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+
+	prefix := binary.AppendUvarint(valueHeader(headerEncrypted), uint64(len(id)))
+	prefix = append(prefix, id...)
+
+	return seal(aead, data, prefix, additionalData(prefix, redisKey))
+}
+
+// decrypt returns the data of a value of redisKey written by encrypt, given the value without its header
+func decrypt(ctx context.Context, provider KeyProvider, redisKey string, val []byte) ([]byte, error) {
+	idLen, n := binary.Uvarint(val)
+	if n <= 0 || uint64(len(val)-n) < idLen {
+		return nil, errors.New("malformed encryption header")
+	}
+
+	id := string(val[n : n+int(idLen)])
+
+	key, err := provider.DecryptionKey(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting decryption key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decryption key: %w", err)
+	}
+
+	prefixLen := n + int(idLen)
+	prefix := append(valueHeader(headerEncrypted), val[:prefixLen]...)
+
+	return open(aead, val[prefixLen:], additionalData(prefix, redisKey))
+}
+
+// additionalData returns the data authenticated with a value, its prefix followed by the redis key it is stored at
+func additionalData(prefix []byte, redisKey string) []byte {
+	return append(append([]byte{}, prefix...), redisKey...)
+}
+
+// newAEAD returns AES-GCM using key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if err := validateKeySize(key); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// validateKeySize checks that key is the size of an AES-128, AES-192 or AES-256 key
+func validateKeySize(key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	}
+
+	return fmt.Errorf("key must be 16, 24 or 32 bytes, not %d", len(key))
+}
+
+// seal encrypts plaintext with a random nonce, returning prefix followed by the nonce and ciphertext. additionalData,
+// which should include prefix, is authenticated but not encrypted or returned.
+func seal(aead cipher.AEAD, plaintext, prefix, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(append([]byte{}, prefix...), nonce...)
+
+	return aead.Seal(out, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a nonce and ciphertext written by seal with the same additional data
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
@@ -0,0 +1,201 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_Encryption(t *testing.T) {
+	ctx := context.Background()
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+
+	Convey("Given a mocked Redis client and a client encrypting values with a static key", t, func() {
+		store := map[string]string{"plain": "unencrypted"}
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				switch v := value.(type) {
+				case string:
+					store[key] = v
+				case []byte:
+					store[key] = string(v)
+				}
+				return redis.NewStatusCmd(ctx, "set", key)
+			},
+			GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+				cmd := redis.NewStringCmd(ctx, "get", key)
+				if val, ok := store[key]; ok {
+					cmd.SetVal(val)
+				} else {
+					cmd.SetErr(redis.Nil)
+				}
+				return cmd
+			},
+		}
+
+		provider, err := NewStaticKeyProvider("2024-01", map[string][]byte{"2024-01": oldKey})
+		So(err, ShouldBeNil)
+
+		client := &Client{redisClient: mockRedisClient, keyProvider: provider}
+
+		Convey("When a value is set and read back", func() {
+			So(client.SetValue(ctx, "session", "user=alice", 0), ShouldBeNil)
+			val, err := client.GetValue(ctx, "session")
+
+			Convey("Then it is stored encrypted with the key ID in its header and read back unchanged", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "user=alice")
+				So(store["session"], ShouldStartWith, string(valueHeader(headerEncrypted)))
+				So(store["session"], ShouldContainSubstring, "2024-01")
+				So(store["session"], ShouldNotContainSubstring, "alice")
+			})
+		})
+
+		Convey("When the key is rotated", func() {
+			So(client.SetValue(ctx, "old", "before rotation", 0), ShouldBeNil)
+
+			rotated, err := NewStaticKeyProvider("2024-02", map[string][]byte{"2024-01": oldKey, "2024-02": newKey})
+			So(err, ShouldBeNil)
+			client.keyProvider = rotated
+
+			So(client.SetValue(ctx, "new", "after rotation", 0), ShouldBeNil)
+			oldVal, oldErr := client.GetValue(ctx, "old")
+			newVal, newErr := client.GetValue(ctx, "new")
+
+			Convey("Then values encrypted with either key can be read", func() {
+				So(oldErr, ShouldBeNil)
+				So(oldVal, ShouldEqual, "before rotation")
+				So(newErr, ShouldBeNil)
+				So(newVal, ShouldEqual, "after rotation")
+				So(store["new"], ShouldContainSubstring, "2024-02")
+			})
+		})
+
+		Convey("When an encrypted value is tampered with", func() {
+			So(client.SetValue(ctx, "session", "user=alice", 0), ShouldBeNil)
+			tampered := []byte(store["session"])
+			tampered[len(tampered)-1] ^= 0xFF
+			store["session"] = string(tampered)
+
+			_, err := client.GetValue(ctx, "session")
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When an encrypted value is copied to another key", func() {
+			So(client.SetValue(ctx, "session", "user=alice", 0), ShouldBeNil)
+			store["stolen"] = store["session"]
+
+			_, err := client.GetValue(ctx, "stolen")
+
+			Convey("Then it cannot be decrypted", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "error decrypting value")
+			})
+		})
+
+		Convey("When a value is encrypted with a key the provider does not hold", func() {
+			other, err := NewStaticKeyProvider("other", map[string][]byte{"other": newKey})
+			So(err, ShouldBeNil)
+			encrypted, err := encrypt(ctx, other, "other", []byte("secret"))
+			So(err, ShouldBeNil)
+			store["other"] = string(encrypted)
+
+			_, err = client.GetValue(ctx, "other")
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "unknown encryption key")
+			})
+		})
+
+		Convey("When a plain value is read", func() {
+			val, err := client.GetValue(ctx, "plain")
+
+			Convey("Then it is returned unchanged", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "unencrypted")
+			})
+		})
+
+		Convey("When a value that cannot be encrypted is set", func() {
+			err := client.SetValue(ctx, "count", 42, 0)
+
+			Convey("Then an error is returned and nothing is written", func() {
+				So(err, ShouldNotBeNil)
+				So(store, ShouldNotContainKey, "count")
+			})
+		})
+
+		Convey("When values are compressed as well as encrypted", func() {
+			client.compression = CompressionGzip
+			large := strings.Repeat("embargoed;", 500)
+
+			So(client.SetValue(ctx, "stats", large, 0), ShouldBeNil)
+			val, err := client.GetValue(ctx, "stats")
+
+			Convey("Then the value is compressed before it is encrypted", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, large)
+				So(len(store["stats"]), ShouldBeLessThan, len(large))
+			})
+		})
+	})
+
+	Convey("Given a KMS key provider backed by a local KMS", t, func() {
+		kms, err := NewLocalKMS("alias/cache", bytes.Repeat([]byte{3}, 32))
+		So(err, ShouldBeNil)
+
+		provider, err := NewKMSKeyProvider(kms, KMSKeyProviderConfig{KeyID: "alias/cache", DataKeyTTL: time.Minute})
+		So(err, ShouldBeNil)
+
+		now := time.Now()
+		provider.now = func() time.Time { return now }
+
+		Convey("When values are encrypted before and after the data key expires", func() {
+			first, err := encrypt(ctx, provider, "first", []byte("first"))
+			So(err, ShouldBeNil)
+			firstID, _, err := provider.EncryptionKey(ctx)
+			So(err, ShouldBeNil)
+
+			now = now.Add(2 * time.Minute)
+			second, err := encrypt(ctx, provider, "second", []byte("second"))
+			So(err, ShouldBeNil)
+			secondID, _, err := provider.EncryptionKey(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then a new data key is generated and both values can be decrypted by another provider", func() {
+				So(secondID, ShouldNotEqual, firstID)
+
+				reader, err := NewKMSKeyProvider(kms, KMSKeyProviderConfig{KeyID: "alias/cache"})
+				So(err, ShouldBeNil)
+
+				val, err := decrypt(ctx, reader, "first", first[len(valueHeader(headerEncrypted)):])
+				So(err, ShouldBeNil)
+				So(string(val), ShouldEqual, "first")
+
+				val, err = decrypt(ctx, reader, "second", second[len(valueHeader(headerEncrypted)):])
+				So(err, ShouldBeNil)
+				So(string(val), ShouldEqual, "second")
+			})
+		})
+	})
+
+	Convey("When a static key provider is created with an invalid key", t, func() {
+		_, err := NewStaticKeyProvider("short", map[string][]byte{"short": []byte("too short")})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
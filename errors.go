@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// classifiedError is an error from go-redis matched to one of the client's sentinel errors, so that both the sentinel
+// and the original error can be found with errors.Is and errors.As
+type classifiedError struct {
+	kind error
+	err  error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// classifyError wraps err with the sentinel error describing it, such as ErrTimeout or ErrReadOnly. Errors that do not
+// match a sentinel, and redis.Nil, are returned unchanged.
+func classifyError(err error) error {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	var classified *classifiedError
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	kind := errorKind(err)
+	if kind == nil {
+		return err
+	}
+
+	return &classifiedError{kind: kind, err: err}
+}
+
+// errorKind returns the sentinel error describing err, or nil if there is none
+func errorKind(err error) error {
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		switch {
+		case redis.HasErrorPrefix(err, "NOAUTH"), redis.HasErrorPrefix(err, "WRONGPASS"), redis.HasErrorPrefix(err, "NOPERM"):
+			return ErrAuth
+		case redis.HasErrorPrefix(err, "READONLY "):
+			return ErrReadOnly
+		case redis.HasErrorPrefix(err, "OOM "):
+			return ErrOutOfMemory
+		case redis.HasErrorPrefix(err, "WRONGTYPE"):
+			return ErrWrongType
+		case redis.HasErrorPrefix(err, "CROSSSLOT"):
+			return ErrCrossSlot
+		}
+
+		return nil
+	}
+
+	var netErr net.Error
+	isNetErr := errors.As(err, &netErr)
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, redis.ErrPoolTimeout), isNetErr && netErr.Timeout():
+		return ErrTimeout
+	case errors.Is(err, redis.ErrClosed), errors.Is(err, redis.ErrPoolExhausted), errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF), isNetErr:
+		return ErrConnection
+	}
+
+	return nil
+}
+
+// retryableReplies are the prefixes of error replies sent by redis while it is temporarily unable to serve commands
+var retryableReplies = []string{"LOADING ", "TRYAGAIN ", "CLUSTERDOWN ", "MASTERDOWN ", "BUSY ", "max number of clients reached"}
+
+// IsRetryable reports whether err is a transient failure that may succeed if the call is retried, such as a timeout,
+// a connection error, a failover in progress or an open circuit breaker. Other errors, such as ErrAuth, ErrWrongType
+// and ErrKeyNotFound, are permanent.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	err = classifyError(err)
+
+	switch {
+	case errors.Is(err, ErrTimeout), errors.Is(err, ErrConnection), errors.Is(err, ErrReadOnly),
+		errors.Is(err, ErrCircuitOpen), errors.Is(err, ErrTransactionConflict):
+		return true
+	}
+
+	for _, prefix := range retryableReplies {
+		if redis.HasErrorPrefix(err, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// errorClassifier is a go-redis hook wrapping the errors of commands with the client's sentinel errors
+type errorClassifier struct{}
+
+// DialHook does not change how connections are made
+func (errorClassifier) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook classifies the error of the command
+func (errorClassifier) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if err == nil {
+			return nil
+		}
+
+		classifyCmd(cmd)
+		return classifyError(err)
+	}
+}
+
+// ProcessPipelineHook classifies the errors of every command in the pipeline
+func (errorClassifier) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		if err == nil {
+			return nil
+		}
+
+		for _, cmd := range cmds {
+			classifyCmd(cmd)
+		}
+		return classifyError(err)
+	}
+}
+
+// classifyCmd replaces the error of cmd with its classified error
+func classifyCmd(cmd redis.Cmder) {
+	if err := cmd.Err(); err != nil {
+		cmd.SetErr(classifyError(err))
+	}
+}
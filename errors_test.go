@@ -0,0 +1,125 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClassifyError(t *testing.T) {
+	Convey("Given errors returned by go-redis", t, func() {
+		timeout := &net.OpError{Op: "read", Net: "tcp", Err: &timeoutError{}}
+		refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+		cases := []struct {
+			err       error
+			kind      error
+			retryable bool
+		}{
+			{err: context.DeadlineExceeded, kind: ErrTimeout, retryable: true},
+			{err: redis.ErrPoolTimeout, kind: ErrTimeout, retryable: true},
+			{err: timeout, kind: ErrTimeout, retryable: true},
+			{err: refused, kind: ErrConnection, retryable: true},
+			{err: io.EOF, kind: ErrConnection, retryable: true},
+			{err: redis.ErrClosed, kind: ErrConnection, retryable: true},
+			{err: testRedisError("NOAUTH Authentication required."), kind: ErrAuth},
+			{err: testRedisError("WRONGPASS invalid username-password pair"), kind: ErrAuth},
+			{err: testRedisError("NOPERM this user has no permissions to run the 'set' command"), kind: ErrAuth},
+			{err: testRedisError("READONLY You can't write against a read only replica."), kind: ErrReadOnly, retryable: true},
+			{err: testRedisError("OOM command not allowed when used memory > 'maxmemory'."), kind: ErrOutOfMemory},
+			{err: testRedisError("WRONGTYPE Operation against a key holding the wrong kind of value"), kind: ErrWrongType},
+			{err: testRedisError("CROSSSLOT Keys in request don't hash to the same slot"), kind: ErrCrossSlot},
+		}
+
+		Convey("When they are classified", func() {
+			Convey("Then each matches its sentinel error and the original error", func() {
+				for _, c := range cases {
+					classified := classifyError(c.err)
+					So(errors.Is(classified, c.kind), ShouldBeTrue)
+					So(errors.Is(classified, c.err), ShouldBeTrue)
+					So(classified.Error(), ShouldEqual, c.err.Error())
+					So(IsRetryable(classified), ShouldEqual, c.retryable)
+					So(IsRetryable(c.err), ShouldEqual, c.retryable)
+				}
+			})
+
+			Convey("Then redis.Nil and unknown replies are returned unchanged", func() {
+				So(classifyError(redis.Nil), ShouldEqual, redis.Nil)
+				unknown := testRedisError("ERR unknown command")
+				So(classifyError(unknown), ShouldEqual, unknown)
+				So(IsRetryable(unknown), ShouldBeFalse)
+			})
+
+			Convey("Then transient redis replies and client errors are retryable", func() {
+				So(IsRetryable(testRedisError("LOADING Redis is loading the dataset in memory")), ShouldBeTrue)
+				So(IsRetryable(testRedisError("TRYAGAIN Multiple keys request during rehashing of slot")), ShouldBeTrue)
+				So(IsRetryable(testRedisError("BUSYGROUP Consumer Group name already exists")), ShouldBeFalse)
+				So(IsRetryable(ErrCircuitOpen), ShouldBeTrue)
+				So(IsRetryable(ErrTransactionConflict), ShouldBeTrue)
+				So(IsRetryable(ErrKeyNotFound), ShouldBeFalse)
+				So(IsRetryable(context.Canceled), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a client around a go-redis client whose node has become a replica", t, func() {
+		ctx := context.Background()
+
+		redisClient := redis.NewClient(&redis.Options{Addr: testAddress})
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, redisClient)
+		redisClient.AddHook(&stubHook{respond: func(cmd redis.Cmder) {
+			cmd.SetErr(testRedisError("READONLY You can't write against a read only replica."))
+		}})
+
+		Convey("When a value is set", func() {
+			err := client.SetValue(ctx, TestKey, TestValue, 0)
+
+			Convey("Then the error is ErrReadOnly and can be retried", func() {
+				So(errors.Is(err, ErrReadOnly), ShouldBeTrue)
+				So(IsRetryable(err), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a client around a custom client that cannot connect to redis", t, func() {
+		ctx := context.Background()
+		refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+				return redis.NewStringResult("", refused)
+			},
+			SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				return redis.NewStatusResult("", refused)
+			},
+		}
+		client := &Client{redisClient: mockRedisClient}
+
+		Convey("When a value is read and written", func() {
+			_, readErr := client.GetValue(ctx, TestKey)
+			writeErr := client.SetValue(ctx, TestKey, TestValue, 0)
+
+			Convey("Then neither error is classified, as the client has no hooks, but both can be retried", func() {
+				So(errors.Is(readErr, ErrConnection), ShouldBeFalse)
+				So(errors.Is(writeErr, ErrConnection), ShouldBeFalse)
+				So(IsRetryable(readErr), ShouldBeTrue)
+				So(IsRetryable(writeErr), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+// timeoutError is a network error reporting a timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
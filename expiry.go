@@ -0,0 +1,172 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NoExpiration is returned by TTL for keys that exist but do not expire.
+const NoExpiration time.Duration = -1
+
+// TTL returns the remaining time to live of key, NoExpiration if the key does not expire, or ErrKeyNotFound if the key
+// does not exist.
+func (cli *Client) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := cli.redisClient.PTTL(ctx, cli.key(key)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error getting ttl for key %s: %w", key, err)
+	}
+
+	switch ttl {
+	case -2:
+		return 0, ErrKeyNotFound
+	case -1:
+		return NoExpiration, nil
+	}
+
+	return ttl, nil
+}
+
+// Expire sets key to expire after expiration, replacing any existing expiry. ErrKeyNotFound is returned if the key
+// does not exist. The expiration must be positive, as redis deletes a key given an expiry that has already passed.
+func (cli *Client) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	if expiration <= 0 {
+		return errors.New("expiration must be positive")
+	}
+
+	set, err := cli.redisClient.PExpire(ctx, cli.key(key), expiration).Result()
+	if err != nil {
+		return fmt.Errorf("error setting expiry for key %s: %w", key, err)
+	}
+
+	if !set {
+		return ErrKeyNotFound
+	}
+
+	return nil
+}
+
+// ExpireAt sets key to expire at tm, replacing any existing expiry. ErrKeyNotFound is returned if the key does not exist.
+// The time must be in the future, as redis deletes a key given an expiry that has already passed.
+func (cli *Client) ExpireAt(ctx context.Context, key string, tm time.Time) error {
+	if !tm.After(time.Now()) {
+		return errors.New("expiry time must be in the future")
+	}
+
+	set, err := cli.redisClient.PExpireAt(ctx, cli.key(key), tm).Result()
+	if err != nil {
+		return fmt.Errorf("error setting expiry for key %s: %w", key, err)
+	}
+
+	if !set {
+		return ErrKeyNotFound
+	}
+
+	return nil
+}
+
+// Persist removes the expiry from key so that it no longer expires. It reports whether an expiry was removed, which is
+// false if the key does not exist or did not have an expiry.
+func (cli *Client) Persist(ctx context.Context, key string) (bool, error) {
+	removed, err := cli.redisClient.Persist(ctx, cli.key(key)).Result()
+	if err != nil {
+		return false, fmt.Errorf("error removing expiry for key %s: %w", key, err)
+	}
+
+	return removed, nil
+}
+
+// GetEx retrieves the value of key and resets its expiry to expiration, e.g. to slide the expiry of a session each
+// time it is read. An expiration of zero removes the key's expiry. ErrKeyNotFound is returned if the key does not exist.
+func (cli *Client) GetEx(ctx context.Context, key string, expiration time.Duration) (string, error) {
+	if expiration < 0 {
+		return "", errors.New("expiration cannot be negative")
+	}
+
+	val, err := cli.redisClient.GetEx(ctx, cli.key(key), expiration).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrKeyNotFound
+	} else if err != nil {
+		return "", fmt.Errorf("error getting value for key %s: %w", key, err)
+	}
+
+	val, err = cli.decodeValue(ctx, cli.key(key), val)
+	if err != nil {
+		return "", fmt.Errorf("error decoding value for key %s: %w", key, err)
+	}
+
+	return val, nil
+}
+
+// SetNX sets a key-value pair with an optional expiration time only if the key does not already exist. It reports
+// whether the value was set.
+func (cli *Client) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	value, err := cli.encodeValue(ctx, cli.key(key), value)
+	if err != nil {
+		return false, fmt.Errorf("error encoding value for key %s: %w", key, err)
+	}
+
+	set, err := cli.redisClient.SetNX(ctx, cli.key(key), value, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("error setting value for key %s: %w", key, err)
+	}
+
+	return set, nil
+}
+
+// SetXX sets a key-value pair with an optional expiration time only if the key already exists. It reports whether the
+// value was set.
+func (cli *Client) SetXX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	value, err := cli.encodeValue(ctx, cli.key(key), value)
+	if err != nil {
+		return false, fmt.Errorf("error encoding value for key %s: %w", key, err)
+	}
+
+	set, err := cli.redisClient.SetXX(ctx, cli.key(key), value, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("error setting value for key %s: %w", key, err)
+	}
+
+	return set, nil
+}
+
+// SetKeepTTL sets the value of key without changing its existing expiry. A new key is created without an expiry.
+func (cli *Client) SetKeepTTL(ctx context.Context, key string, value interface{}) error {
+	value, err := cli.encodeValue(ctx, cli.key(key), value)
+	if err != nil {
+		return fmt.Errorf("error encoding value for key %s: %w", key, err)
+	}
+
+	err = cli.redisClient.Set(ctx, cli.key(key), value, redis.KeepTTL).Err()
+	if err != nil {
+		return fmt.Errorf("error setting value for key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// SetGet sets a key-value pair with an optional expiration time and returns the value it replaced. existed is false
+// if the key did not exist before it was set.
+func (cli *Client) SetGet(ctx context.Context, key string, value interface{}, expiration time.Duration) (previous string, existed bool, err error) {
+	value, err = cli.encodeValue(ctx, cli.key(key), value)
+	if err != nil {
+		return "", false, fmt.Errorf("error encoding value for key %s: %w", key, err)
+	}
+
+	previous, err = cli.redisClient.SetArgs(ctx, cli.key(key), value, redis.SetArgs{TTL: expiration, Get: true}).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, fmt.Errorf("error setting value for key %s: %w", key, err)
+	}
+
+	previous, err = cli.decodeValue(ctx, cli.key(key), previous)
+	if err != nil {
+		return "", true, fmt.Errorf("error decoding previous value for key %s: %w", key, err)
+	}
+
+	return previous, true, nil
+}
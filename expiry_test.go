@@ -0,0 +1,202 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_Expiry(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mocked Redis client holding a key with an expiry, a key without one and a missing key", t, func() {
+		ttls := map[string]time.Duration{"session": time.Minute, "forever": -1, "missing": -2}
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			PTTLFunc: func(ctx context.Context, key string) *redis.DurationCmd {
+				cmd := redis.NewDurationCmd(ctx, time.Millisecond, "pttl", key)
+				cmd.SetVal(ttls[key])
+				return cmd
+			},
+			PExpireFunc: func(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+				cmd := redis.NewBoolCmd(ctx, "pexpire", key)
+				cmd.SetVal(key != "missing")
+				return cmd
+			},
+			PExpireAtFunc: func(ctx context.Context, key string, tm time.Time) *redis.BoolCmd {
+				cmd := redis.NewBoolCmd(ctx, "pexpireat", key)
+				cmd.SetVal(key != "missing")
+				return cmd
+			},
+			PersistFunc: func(ctx context.Context, key string) *redis.BoolCmd {
+				cmd := redis.NewBoolCmd(ctx, "persist", key)
+				cmd.SetVal(key == "session")
+				return cmd
+			},
+			GetExFunc: func(ctx context.Context, key string, expiration time.Duration) *redis.StringCmd {
+				cmd := redis.NewStringCmd(ctx, "getex", key)
+				if key == "missing" {
+					cmd.SetErr(redis.Nil)
+				} else {
+					cmd.SetVal("val_for_" + key)
+				}
+				return cmd
+			},
+		}
+
+		client := &Client{redisClient: mockRedisClient}
+
+		Convey("When the TTLs are requested", func() {
+			sessionTTL, sessionErr := client.TTL(ctx, "session")
+			foreverTTL, foreverErr := client.TTL(ctx, "forever")
+			_, missingErr := client.TTL(ctx, "missing")
+
+			Convey("Then the remaining time, NoExpiration and ErrKeyNotFound are returned", func() {
+				So(sessionErr, ShouldBeNil)
+				So(sessionTTL, ShouldEqual, time.Minute)
+				So(foreverErr, ShouldBeNil)
+				So(foreverTTL, ShouldEqual, NoExpiration)
+				So(missingErr, ShouldEqual, ErrKeyNotFound)
+			})
+		})
+
+		Convey("When expiries are set", func() {
+			deadline := time.Now().Add(time.Hour)
+
+			So(client.Expire(ctx, "session", time.Hour), ShouldBeNil)
+			So(client.ExpireAt(ctx, "session", deadline), ShouldBeNil)
+
+			Convey("Then the expiry is sent for the key", func() {
+				So(mockRedisClient.PExpireCalls()[0].Expiration, ShouldEqual, time.Hour)
+				So(mockRedisClient.PExpireAtCalls()[0].Tm, ShouldEqual, deadline)
+			})
+
+			Convey("Then ErrKeyNotFound is returned for a missing key", func() {
+				So(client.Expire(ctx, "missing", time.Hour), ShouldEqual, ErrKeyNotFound)
+				So(client.ExpireAt(ctx, "missing", deadline), ShouldEqual, ErrKeyNotFound)
+			})
+
+			Convey("Then expiries that have already passed are rejected rather than deleting the key", func() {
+				So(client.Expire(ctx, "session", 0), ShouldNotBeNil)
+				So(client.Expire(ctx, "session", -time.Second), ShouldNotBeNil)
+				So(client.ExpireAt(ctx, "session", time.Now().Add(-time.Second)), ShouldNotBeNil)
+				So(mockRedisClient.PExpireCalls(), ShouldHaveLength, 1)
+				So(mockRedisClient.PExpireAtCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When expiries are removed", func() {
+			removed, err := client.Persist(ctx, "session")
+			So(err, ShouldBeNil)
+			notRemoved, err := client.Persist(ctx, "forever")
+			So(err, ShouldBeNil)
+
+			Convey("Then whether an expiry was removed is reported", func() {
+				So(removed, ShouldBeTrue)
+				So(notRemoved, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a value is read and its expiry refreshed", func() {
+			val, err := client.GetEx(ctx, "session", 30*time.Minute)
+
+			Convey("Then the value is returned and the new expiry sent", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "val_for_session")
+				So(mockRedisClient.GetExCalls()[0].Expiration, ShouldEqual, 30*time.Minute)
+			})
+
+			Convey("Then ErrKeyNotFound is returned for a missing key", func() {
+				_, err := client.GetEx(ctx, "missing", time.Minute)
+				So(err, ShouldEqual, ErrKeyNotFound)
+			})
+
+			Convey("Then a negative expiry is rejected", func() {
+				_, err := client.GetEx(ctx, "session", -time.Second)
+				So(err, ShouldNotBeNil)
+				So(mockRedisClient.GetExCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestClient_ConditionalSet(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mocked Redis client holding a single key", t, func() {
+		existing := "existing"
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			SetNXFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+				cmd := redis.NewBoolCmd(ctx, "set", key, value, "nx")
+				cmd.SetVal(key != existing)
+				return cmd
+			},
+			SetXXFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+				cmd := redis.NewBoolCmd(ctx, "set", key, value, "xx")
+				cmd.SetVal(key == existing)
+				return cmd
+			},
+			SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx, "set", key, value)
+				cmd.SetVal("OK")
+				return cmd
+			},
+			SetArgsFunc: func(ctx context.Context, key string, value interface{}, a redis.SetArgs) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx, "set", key, value, "get")
+				if key == existing {
+					cmd.SetVal("old")
+				} else {
+					cmd.SetErr(redis.Nil)
+				}
+				return cmd
+			},
+		}
+
+		client := &Client{redisClient: mockRedisClient}
+
+		Convey("When values are set only if the key does or does not exist", func() {
+			setNew, err := client.SetNX(ctx, "new", TestValue, time.Minute)
+			So(err, ShouldBeNil)
+			setExisting, err := client.SetNX(ctx, existing, TestValue, time.Minute)
+			So(err, ShouldBeNil)
+			replaced, err := client.SetXX(ctx, existing, TestValue, 0)
+			So(err, ShouldBeNil)
+			created, err := client.SetXX(ctx, "new", TestValue, 0)
+			So(err, ShouldBeNil)
+
+			Convey("Then whether each value was set is reported", func() {
+				So(setNew, ShouldBeTrue)
+				So(setExisting, ShouldBeFalse)
+				So(replaced, ShouldBeTrue)
+				So(created, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a value is set keeping its expiry", func() {
+			So(client.SetKeepTTL(ctx, existing, TestValue), ShouldBeNil)
+
+			Convey("Then KEEPTTL is requested", func() {
+				So(mockRedisClient.SetCalls()[0].Expiration, ShouldEqual, redis.KeepTTL)
+			})
+		})
+
+		Convey("When values are set returning the previous value", func() {
+			previous, existed, err := client.SetGet(ctx, existing, TestValue, time.Minute)
+			So(err, ShouldBeNil)
+			_, newExisted, err := client.SetGet(ctx, "new", TestValue, time.Minute)
+			So(err, ShouldBeNil)
+
+			Convey("Then the previous value is returned if there was one", func() {
+				So(previous, ShouldEqual, "old")
+				So(existed, ShouldBeTrue)
+				So(newExisted, ShouldBeFalse)
+				So(mockRedisClient.SetArgsCalls()[0].A, ShouldResemble, redis.SetArgs{TTL: time.Minute, Get: true})
+			})
+		})
+	})
+}
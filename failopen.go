@@ -0,0 +1,268 @@
+package redis
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const defaultFailOpenRetryInterval = time.Second
+
+// FailOpenConfig configures fail-open mode, in which redis failures are treated as cache misses rather than errors.
+type FailOpenConfig struct {
+	// WriteBufferSize is the maximum number of failed writes held in memory and retried until redis recovers. Only the
+	// latest failed write to each key is kept. If zero, failed writes are dropped.
+	WriteBufferSize int
+	// RetryInterval is how often buffered writes are retried. Defaults to one second.
+	RetryInterval time.Duration
+}
+
+// FailOpenStats counts the errors suppressed in fail-open mode.
+type FailOpenStats struct {
+	// SuppressedReads is the number of failed reads returned as misses
+	SuppressedReads int64
+	// SuppressedWrites is the number of failed writes reported as successful
+	SuppressedWrites int64
+	// DroppedWrites is the number of suppressed writes that were not buffered, or were evicted from a full buffer
+	DroppedWrites int64
+	// RetriedWrites is the number of buffered writes that were applied after redis recovered
+	RetriedWrites int64
+}
+
+// Validate will validate that the fail-open config is usable
+func (c *FailOpenConfig) Validate() error {
+	if c.WriteBufferSize < 0 {
+		return errors.New("write buffer size cannot be negative")
+	}
+
+	if c.RetryInterval < 0 {
+		return errors.New("retry interval cannot be negative")
+	}
+
+	return nil
+}
+
+// failOpenKey is the context key of a per-call fail-open override
+type failOpenKey struct{}
+
+// WithFailOpen returns a context that enables or disables fail-open mode for calls made with it, overriding
+// ClientConfig.FailOpen.
+func WithFailOpen(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, failOpenKey{}, enabled)
+}
+
+// failOpen reports whether redis failures are suppressed for calls made with ctx
+func (cli *Client) failOpen(ctx context.Context) bool {
+	if enabled, ok := ctx.Value(failOpenKey{}).(bool); ok {
+		return enabled
+	}
+
+	return cli.failOpenConfig != nil
+}
+
+// suppressRead reports whether a failed read should be returned as a miss in fail-open mode, counting and logging
+// the error if so
+func (cli *Client) suppressRead(ctx context.Context, key string, err error) bool {
+	if err == nil || errors.Is(err, ErrKeyNotFound) || !cli.failOpen(ctx) {
+		return false
+	}
+
+	cli.failOpenStats.suppressedReads.Add(1)
+	log.Error(ctx, "redis read failed, returning a miss in fail-open mode", err, log.Data{"key": key})
+
+	return true
+}
+
+// suppressWrite reports a failed write to key as successful in fail-open mode, buffering retry to be applied once
+// redis recovers if the client has a write buffer. A successful write discards any buffered write to the same key.
+func (cli *Client) suppressWrite(ctx context.Context, key string, err error, retry func(ctx context.Context) error) error {
+	if err == nil {
+		cli.writeBuffer.discard(key)
+		return nil
+	}
+
+	if errors.Is(err, ErrKeyNotFound) || !cli.failOpen(ctx) {
+		return err
+	}
+
+	cli.failOpenStats.suppressedWrites.Add(1)
+	logData := log.Data{"key": key}
+
+	if cli.writeBuffer == nil {
+		cli.failOpenStats.droppedWrites.Add(1)
+		log.Error(ctx, "redis write failed, dropping it in fail-open mode", err, logData)
+		return nil
+	}
+
+	if evicted := cli.writeBuffer.add(key, retry); evicted {
+		cli.failOpenStats.droppedWrites.Add(1)
+	}
+	log.Error(ctx, "redis write failed, buffering it for retry in fail-open mode", err, logData)
+
+	return nil
+}
+
+// FailOpenStats returns the number of errors suppressed in fail-open mode since the client was created.
+func (cli *Client) FailOpenStats() FailOpenStats {
+	return FailOpenStats{
+		SuppressedReads:  cli.failOpenStats.suppressedReads.Load(),
+		SuppressedWrites: cli.failOpenStats.suppressedWrites.Load(),
+		DroppedWrites:    cli.failOpenStats.droppedWrites.Load(),
+		RetriedWrites:    cli.failOpenStats.retriedWrites.Load(),
+	}
+}
+
+// failOpenStats holds the counters reported by FailOpenStats
+type failOpenStats struct {
+	suppressedReads  atomic.Int64
+	suppressedWrites atomic.Int64
+	droppedWrites    atomic.Int64
+	retriedWrites    atomic.Int64
+}
+
+// writeBuffer holds writes that failed in fail-open mode and retries them in order until they succeed
+type writeBuffer struct {
+	client *Client
+	size   int
+
+	mu      sync.Mutex
+	writes  map[string]*list.Element
+	pending *list.List
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// bufferedWrite is a failed write held by a writeBuffer
+type bufferedWrite struct {
+	key   string
+	write func(ctx context.Context) error
+}
+
+// newWriteBuffer returns a writeBuffer for the client, retrying writes every interval until the client is closed
+func newWriteBuffer(cli *Client, size int, interval time.Duration) *writeBuffer {
+	if interval == 0 {
+		interval = defaultFailOpenRetryInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &writeBuffer{
+		client:  cli,
+		size:    size,
+		writes:  make(map[string]*list.Element),
+		pending: list.New(),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(b.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				b.flush(ctx)
+			}
+		}
+	}()
+
+	cli.addCloser(b, b.close)
+
+	return b
+}
+
+// add buffers the write to key, replacing any earlier write to the key. It reports whether the oldest write was
+// evicted because the buffer was full.
+func (b *writeBuffer) add(key string, write func(ctx context.Context) error) (evicted bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elem, ok := b.writes[key]; ok {
+		b.pending.Remove(elem)
+	}
+
+	b.writes[key] = b.pending.PushBack(&bufferedWrite{key: key, write: write})
+
+	if len(b.writes) > b.size {
+		oldest := b.pending.Front()
+		b.pending.Remove(oldest)
+		delete(b.writes, oldest.Value.(*bufferedWrite).key)
+		return true
+	}
+
+	return false
+}
+
+// discard removes any buffered write to key, as it has been superseded by a successful write
+func (b *writeBuffer) discard(key string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elem, ok := b.writes[key]; ok {
+		b.pending.Remove(elem)
+		delete(b.writes, key)
+	}
+}
+
+// flush applies the buffered writes in order, stopping at the first write that fails
+func (b *writeBuffer) flush(ctx context.Context) {
+	for ctx.Err() == nil {
+		b.mu.Lock()
+		elem := b.pending.Front()
+		b.mu.Unlock()
+
+		if elem == nil {
+			return
+		}
+
+		if err := elem.Value.(*bufferedWrite).write(ctx); err != nil {
+			return
+		}
+
+		b.mu.Lock()
+		if b.writes[elem.Value.(*bufferedWrite).key] == elem {
+			b.pending.Remove(elem)
+			delete(b.writes, elem.Value.(*bufferedWrite).key)
+		}
+		b.mu.Unlock()
+
+		b.client.failOpenStats.retriedWrites.Add(1)
+	}
+}
+
+// Len returns the number of buffered writes
+func (b *writeBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.writes)
+}
+
+// close stops retrying buffered writes, waiting for a retry in progress to finish or for ctx to be done. Writes that
+// are still buffered are lost.
+func (b *writeBuffer) close(ctx context.Context) error {
+	b.cancel()
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for buffered writes to stop: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,145 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_FailOpen(t *testing.T) {
+	ctx := context.Background()
+	outage := errors.New("dial tcp: connection refused")
+
+	Convey("Given a mocked Redis client that is unavailable", t, func() {
+		var redisErr error = outage
+		store := map[string]interface{}{}
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+				cmd := redis.NewStringCmd(ctx, "get", key)
+				cmd.SetErr(redisErr)
+				return cmd
+			},
+			ScanFunc: func(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+				cmd := redis.NewScanCmd(ctx, nil, "scan", cursor)
+				cmd.SetErr(redisErr)
+				return cmd
+			},
+			SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx, "set", key)
+				if redisErr != nil {
+					cmd.SetErr(redisErr)
+				} else {
+					store[key] = value
+				}
+				return cmd
+			},
+			DelFunc: func(ctx context.Context, keys ...string) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "del")
+				cmd.SetErr(redisErr)
+				return cmd
+			},
+		}
+
+		Convey("And a client in fail-open mode that drops failed writes", func() {
+			client := NewClientWithCustomClient(ctx, &ClientConfig{FailOpen: &FailOpenConfig{}}, mockRedisClient)
+
+			Convey("When values are read", func() {
+				_, getErr := client.GetValue(ctx, "navigation")
+				pairs, cursor, scanErr := client.GetKeyValuePairs(ctx, "*", 10, 0)
+
+				Convey("Then the failures are returned as misses and counted", func() {
+					So(getErr, ShouldEqual, ErrKeyNotFound)
+					So(scanErr, ShouldBeNil)
+					So(pairs, ShouldBeEmpty)
+					So(cursor, ShouldEqual, 0)
+					So(client.FailOpenStats().SuppressedReads, ShouldEqual, 2)
+				})
+			})
+
+			Convey("When values are written and deleted", func() {
+				setErr := client.SetValue(ctx, "navigation", "value", 0)
+				delErr := client.DeleteValue(ctx, "navigation")
+
+				Convey("Then the writes are dropped without an error and counted", func() {
+					So(setErr, ShouldBeNil)
+					So(delErr, ShouldBeNil)
+					stats := client.FailOpenStats()
+					So(stats.SuppressedWrites, ShouldEqual, 2)
+					So(stats.DroppedWrites, ShouldEqual, 2)
+				})
+			})
+
+			Convey("When fail-open is disabled for a call", func() {
+				_, err := client.GetValue(WithFailOpen(ctx, false), "navigation")
+
+				Convey("Then the error is returned", func() {
+					So(errors.Is(err, outage), ShouldBeTrue)
+					So(client.FailOpenStats().SuppressedReads, ShouldEqual, 0)
+				})
+			})
+		})
+
+		Convey("And a client without fail-open mode", func() {
+			client := NewClientWithCustomClient(ctx, &ClientConfig{}, mockRedisClient)
+
+			Convey("When fail-open is enabled for a call", func() {
+				_, defaultErr := client.GetValue(ctx, "navigation")
+				_, failOpenErr := client.GetValue(WithFailOpen(ctx, true), "navigation")
+
+				Convey("Then only that call returns a miss", func() {
+					So(errors.Is(defaultErr, outage), ShouldBeTrue)
+					So(failOpenErr, ShouldEqual, ErrKeyNotFound)
+				})
+			})
+		})
+
+		Convey("And a client in fail-open mode that buffers failed writes", func() {
+			client := NewClientWithCustomClient(ctx, &ClientConfig{FailOpen: &FailOpenConfig{
+				WriteBufferSize: 2,
+				RetryInterval:   time.Hour,
+			}}, mockRedisClient)
+			defer client.writeBuffer.close(ctx)
+
+			So(client.SetValue(ctx, "a", "first", 0), ShouldBeNil)
+			So(client.SetValue(ctx, "a", "second", 0), ShouldBeNil)
+			So(client.SetValue(ctx, "b", "value", 0), ShouldBeNil)
+
+			Convey("When the buffer is flushed after redis recovers", func() {
+				redisErr = nil
+				client.writeBuffer.flush(ctx)
+
+				Convey("Then the latest write to each key is applied", func() {
+					So(store, ShouldResemble, map[string]interface{}{"a": "second", "b": "value"})
+					So(client.writeBuffer.Len(), ShouldEqual, 0)
+					So(client.FailOpenStats().RetriedWrites, ShouldEqual, 2)
+				})
+			})
+
+			Convey("When more writes fail than the buffer holds", func() {
+				So(client.SetValue(ctx, "c", "value", 0), ShouldBeNil)
+
+				Convey("Then the oldest write is dropped", func() {
+					So(client.writeBuffer.Len(), ShouldEqual, 2)
+					So(client.FailOpenStats().DroppedWrites, ShouldEqual, 1)
+				})
+			})
+
+			Convey("When a later write to a buffered key succeeds", func() {
+				redisErr = nil
+				So(client.SetValue(ctx, "a", "third", 0), ShouldBeNil)
+				client.writeBuffer.flush(ctx)
+
+				Convey("Then the buffered write is discarded", func() {
+					So(store["a"], ShouldEqual, "third")
+					So(client.FailOpenStats().RetriedWrites, ShouldEqual, 1)
+				})
+			})
+		})
+	})
+}
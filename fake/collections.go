@@ -0,0 +1,557 @@
+package fake
+
+import (
+	"math"
+	"slices"
+	"strconv"
+)
+
+// normaliseRange converts the inclusive start and stop indexes of a range of n elements, which may count back from
+// the end if negative, to indexes within the elements. It reports false if the range is empty.
+func normaliseRange(start, stop int64, n int) (int, int, bool) {
+	if start < 0 {
+		start += int64(n)
+	}
+	if stop < 0 {
+		stop += int64(n)
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= int64(n) {
+		stop = int64(n) - 1
+	}
+
+	if start > stop || start >= int64(n) {
+		return 0, 0, false
+	}
+
+	return int(start), int(stop), true
+}
+
+// addFloat adds an increment to a float value, failing if the result is not a finite number
+func addFloat(value, increment float64) (float64, error) {
+	result := value + increment
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, errIncrementNaN
+	}
+
+	return result, nil
+}
+
+func cmdHSet(s *Server, args []string) any {
+	if len(args)%2 != 1 {
+		return wrongArity("hset")
+	}
+
+	h, err := lookupOrCreate(s, args[0], func() hash { return hash{} })
+	if err != nil {
+		return err
+	}
+
+	var added int
+	for i := 1; i < len(args); i += 2 {
+		if _, exists := h[args[i]]; !exists {
+			added++
+		}
+		h[args[i]] = args[i+1]
+	}
+
+	s.touch(args[0])
+
+	return added
+}
+
+func cmdHMSet(s *Server, args []string) any {
+	if len(args)%2 != 1 {
+		return wrongArity("hmset")
+	}
+
+	if reply := cmdHSet(s, args); isError(reply) {
+		return reply
+	}
+
+	return replyOK
+}
+
+func cmdHSetNX(s *Server, args []string) any {
+	h, err := lookupOrCreate(s, args[0], func() hash { return hash{} })
+	if err != nil {
+		return err
+	}
+
+	if _, exists := h[args[1]]; exists {
+		return 0
+	}
+
+	h[args[1]] = args[2]
+	s.touch(args[0])
+
+	return 1
+}
+
+func cmdHGet(s *Server, args []string) any {
+	h, _, err := lookupValue[hash](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	if value, exists := h[args[1]]; exists {
+		return value
+	}
+
+	return nil
+}
+
+func cmdHMGet(s *Server, args []string) any {
+	h, _, err := lookupValue[hash](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(args)-1)
+	for i, field := range args[1:] {
+		if value, exists := h[field]; exists {
+			values[i] = value
+		}
+	}
+
+	return values
+}
+
+func cmdHGetAll(s *Server, args []string) any {
+	h, _, err := lookupValue[hash](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	pairs := make([]string, 0, len(h)*2)
+	for _, field := range sortedKeys(h) {
+		pairs = append(pairs, field, h[field])
+	}
+
+	return pairs
+}
+
+func cmdHDel(s *Server, args []string) any {
+	h, exists, err := lookupValue[hash](s, args[0])
+	if err != nil || !exists {
+		return replyOrZero(err)
+	}
+
+	var deleted int
+	for _, field := range args[1:] {
+		if _, exists := h[field]; exists {
+			delete(h, field)
+			deleted++
+		}
+	}
+
+	if deleted > 0 {
+		s.removeIfEmpty(args[0], len(h))
+	}
+
+	return deleted
+}
+
+func cmdHExists(s *Server, args []string) any {
+	h, _, err := lookupValue[hash](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	_, exists := h[args[1]]
+	return exists
+}
+
+func cmdHLen(s *Server, args []string) any {
+	h, _, err := lookupValue[hash](s, args[0])
+	if err != nil {
+		return err
+	}
+	return len(h)
+}
+
+func cmdHKeys(s *Server, args []string) any {
+	h, _, err := lookupValue[hash](s, args[0])
+	if err != nil {
+		return err
+	}
+	return sortedKeys(h)
+}
+
+func cmdHVals(s *Server, args []string) any {
+	h, _, err := lookupValue[hash](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	values := make([]string, 0, len(h))
+	for _, field := range sortedKeys(h) {
+		values = append(values, h[field])
+	}
+
+	return values
+}
+
+func cmdHIncrBy(s *Server, args []string) any {
+	increment, err := parseInt(args[2])
+	if err != nil {
+		return err
+	}
+
+	h, err := lookupOrCreate(s, args[0], func() hash { return hash{} })
+	if err != nil {
+		return err
+	}
+
+	var current int64
+	if value, exists := h[args[1]]; exists {
+		if current, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return replyError("ERR hash value is not an integer")
+		}
+	}
+
+	if (increment > 0 && current > math.MaxInt64-increment) || (increment < 0 && current < math.MinInt64-increment) {
+		return replyError("ERR increment or decrement would overflow")
+	}
+
+	current += increment
+	h[args[1]] = strconv.FormatInt(current, 10)
+	s.touch(args[0])
+
+	return current
+}
+
+func cmdHIncrByFloat(s *Server, args []string) any {
+	increment, err := parseFloat(args[2])
+	if err != nil {
+		return err
+	}
+
+	h, err := lookupOrCreate(s, args[0], func() hash { return hash{} })
+	if err != nil {
+		return err
+	}
+
+	var current float64
+	if value, exists := h[args[1]]; exists {
+		if current, err = parseFloat(value); err != nil {
+			return replyError("ERR hash value is not a float")
+		}
+	}
+
+	result, err := addFloat(current, increment)
+	if err != nil {
+		return err
+	}
+
+	h[args[1]] = formatFloat(result)
+	s.touch(args[0])
+
+	return result
+}
+
+// pushCommand returns the LPUSH command if left is true, or the RPUSH command
+func pushCommand(left bool) func(s *Server, args []string) any {
+	return func(s *Server, args []string) any {
+		l, err := lookupOrCreate(s, args[0], func() *list { return &list{} })
+		if err != nil {
+			return err
+		}
+
+		for _, value := range args[1:] {
+			if left {
+				l.items = slices.Insert(l.items, 0, value)
+			} else {
+				l.items = append(l.items, value)
+			}
+		}
+
+		s.touch(args[0])
+
+		return len(l.items)
+	}
+}
+
+// popCommand returns the LPOP command if left is true, or the RPOP command
+func popCommand(left bool) func(s *Server, args []string) any {
+	return func(s *Server, args []string) any {
+		if len(args) > 2 {
+			return errSyntax
+		}
+
+		count := int64(1)
+		if len(args) == 2 {
+			var err error
+			if count, err = parseInt(args[1]); err != nil || count < 0 {
+				return replyError("ERR value is out of range, must be positive")
+			}
+		}
+
+		l, exists, err := lookupValue[*list](s, args[0])
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			if len(args) == 2 {
+				return nilArray{}
+			}
+			return nil
+		}
+
+		n := min(int(count), len(l.items))
+		popped := make([]string, n)
+		for i := range popped {
+			if left {
+				popped[i] = l.items[i]
+			} else {
+				popped[i] = l.items[len(l.items)-1-i]
+			}
+		}
+
+		if left {
+			l.items = l.items[n:]
+		} else {
+			l.items = l.items[:len(l.items)-n]
+		}
+
+		s.removeIfEmpty(args[0], len(l.items))
+
+		if len(args) == 2 {
+			return popped
+		}
+
+		return popped[0]
+	}
+}
+
+func cmdLLen(s *Server, args []string) any {
+	l, exists, err := lookupValue[*list](s, args[0])
+	if err != nil || !exists {
+		return replyOrZero(err)
+	}
+	return len(l.items)
+}
+
+func cmdLRange(s *Server, args []string) any {
+	start, stop, err := parseRange(args[1], args[2])
+	if err != nil {
+		return err
+	}
+
+	l, exists, err := lookupValue[*list](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return []string{}
+	}
+
+	first, last, ok := normaliseRange(start, stop, len(l.items))
+	if !ok {
+		return []string{}
+	}
+
+	return slices.Clone(l.items[first : last+1])
+}
+
+func cmdLIndex(s *Server, args []string) any {
+	index, err := parseInt(args[1])
+	if err != nil {
+		return err
+	}
+
+	l, exists, err := lookupValue[*list](s, args[0])
+	if err != nil || !exists {
+		return err
+	}
+
+	if index < 0 {
+		index += int64(len(l.items))
+	}
+
+	if index < 0 || index >= int64(len(l.items)) {
+		return nil
+	}
+
+	return l.items[index]
+}
+
+func cmdLSet(s *Server, args []string) any {
+	index, err := parseInt(args[1])
+	if err != nil {
+		return err
+	}
+
+	l, exists, err := lookupValue[*list](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return errNoSuchKey
+	}
+
+	if index < 0 {
+		index += int64(len(l.items))
+	}
+
+	if index < 0 || index >= int64(len(l.items)) {
+		return errOutOfRange
+	}
+
+	l.items[index] = args[2]
+	s.touch(args[0])
+
+	return replyOK
+}
+
+func cmdLTrim(s *Server, args []string) any {
+	start, stop, err := parseRange(args[1], args[2])
+	if err != nil {
+		return err
+	}
+
+	l, exists, err := lookupValue[*list](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return replyOK
+	}
+
+	if first, last, ok := normaliseRange(start, stop, len(l.items)); ok {
+		l.items = slices.Clone(l.items[first : last+1])
+	} else {
+		l.items = nil
+	}
+
+	s.removeIfEmpty(args[0], len(l.items))
+
+	return replyOK
+}
+
+func cmdLRem(s *Server, args []string) any {
+	count, err := parseInt(args[1])
+	if err != nil {
+		return err
+	}
+
+	l, exists, err := lookupValue[*list](s, args[0])
+	if err != nil || !exists {
+		return replyOrZero(err)
+	}
+
+	// A negative count removes elements from the tail, so the list is searched in reverse
+	limit := count
+	if count < 0 {
+		limit = -count
+		slices.Reverse(l.items)
+	}
+
+	var removed int64
+	kept := l.items[:0]
+	for _, item := range l.items {
+		if item == args[2] && (count == 0 || removed < limit) {
+			removed++
+			continue
+		}
+		kept = append(kept, item)
+	}
+	l.items = kept
+
+	if count < 0 {
+		slices.Reverse(l.items)
+	}
+
+	if removed > 0 {
+		s.removeIfEmpty(args[0], len(l.items))
+	}
+
+	return removed
+}
+
+func cmdSAdd(s *Server, args []string) any {
+	members, err := lookupOrCreate(s, args[0], func() set { return set{} })
+	if err != nil {
+		return err
+	}
+
+	var added int
+	for _, member := range args[1:] {
+		if _, exists := members[member]; !exists {
+			members[member] = struct{}{}
+			added++
+		}
+	}
+
+	s.touch(args[0])
+
+	return added
+}
+
+func cmdSRem(s *Server, args []string) any {
+	members, exists, err := lookupValue[set](s, args[0])
+	if err != nil || !exists {
+		return replyOrZero(err)
+	}
+
+	var removed int
+	for _, member := range args[1:] {
+		if _, exists := members[member]; exists {
+			delete(members, member)
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		s.removeIfEmpty(args[0], len(members))
+	}
+
+	return removed
+}
+
+func cmdSMembers(s *Server, args []string) any {
+	members, _, err := lookupValue[set](s, args[0])
+	if err != nil {
+		return err
+	}
+	return sortedKeys(members)
+}
+
+func cmdSIsMember(s *Server, args []string) any {
+	members, _, err := lookupValue[set](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	_, exists := members[args[1]]
+	return exists
+}
+
+func cmdSMIsMember(s *Server, args []string) any {
+	members, _, err := lookupValue[set](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	replies := make([]any, len(args)-1)
+	for i, member := range args[1:] {
+		_, exists := members[member]
+		replies[i] = exists
+	}
+
+	return replies
+}
+
+func cmdSCard(s *Server, args []string) any {
+	members, _, err := lookupValue[set](s, args[0])
+	if err != nil {
+		return err
+	}
+	return len(members)
+}
@@ -0,0 +1,163 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestServer_Hashes(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server holding a hash", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		So(rdb.HSet(ctx, "hash", "a", "1", "b", "2").Err(), ShouldBeNil)
+
+		Convey("When the hash is read", func() {
+			all, err := rdb.HGetAll(ctx, "hash").Result()
+			vals, valsErr := rdb.HMGet(ctx, "hash", "a", "missing").Result()
+
+			Convey("Then its fields are returned", func() {
+				So(err, ShouldBeNil)
+				So(all, ShouldResemble, map[string]string{"a": "1", "b": "2"})
+				So(valsErr, ShouldBeNil)
+				So(vals, ShouldResemble, []interface{}{"1", nil})
+				So(rdb.HLen(ctx, "hash").Val(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When a field is incremented", func() {
+			n, err := rdb.HIncrBy(ctx, "hash", "a", 10).Result()
+
+			Convey("Then the new value is returned", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 11)
+			})
+		})
+
+		Convey("When every field is deleted", func() {
+			n, err := rdb.HDel(ctx, "hash", "a", "b").Result()
+
+			Convey("Then the hash is deleted", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 2)
+				So(rdb.Exists(ctx, "hash").Val(), ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestServer_Lists(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server holding a list", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		So(rdb.RPush(ctx, "list", "b", "c", "b").Err(), ShouldBeNil)
+		So(rdb.LPush(ctx, "list", "a").Err(), ShouldBeNil)
+
+		Convey("When ranges of the list are read", func() {
+			all, err := rdb.LRange(ctx, "list", 0, -1).Result()
+			tail, tailErr := rdb.LRange(ctx, "list", -2, 100).Result()
+
+			Convey("Then the elements are returned in order", func() {
+				So(err, ShouldBeNil)
+				So(all, ShouldResemble, []string{"a", "b", "c", "b"})
+				So(tailErr, ShouldBeNil)
+				So(tail, ShouldResemble, []string{"c", "b"})
+				So(rdb.LIndex(ctx, "list", -1).Val(), ShouldEqual, "b")
+			})
+		})
+
+		Convey("When elements are popped from both ends", func() {
+			first, err := rdb.LPop(ctx, "list").Result()
+			last, lastErr := rdb.RPopCount(ctx, "list", 2).Result()
+
+			Convey("Then they are removed from the list", func() {
+				So(err, ShouldBeNil)
+				So(first, ShouldEqual, "a")
+				So(lastErr, ShouldBeNil)
+				So(last, ShouldResemble, []string{"b", "c"})
+				So(rdb.LRange(ctx, "list", 0, -1).Val(), ShouldResemble, []string{"b"})
+			})
+		})
+
+		Convey("When matching elements are removed from the tail", func() {
+			n, err := rdb.LRem(ctx, "list", -1, "b").Result()
+
+			Convey("Then the last match is removed", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 1)
+				So(rdb.LRange(ctx, "list", 0, -1).Val(), ShouldResemble, []string{"a", "b", "c"})
+			})
+		})
+
+		Convey("When the list is trimmed", func() {
+			err := rdb.LTrim(ctx, "list", 1, 2).Err()
+
+			Convey("Then only the range is kept", func() {
+				So(err, ShouldBeNil)
+				So(rdb.LRange(ctx, "list", 0, -1).Val(), ShouldResemble, []string{"b", "c"})
+			})
+		})
+
+		Convey("When an element is popped from a missing list", func() {
+			_, err := rdb.LPop(ctx, "missing").Result()
+
+			Convey("Then nil is returned", func() {
+				So(err, ShouldEqual, redis.Nil)
+			})
+		})
+	})
+}
+
+func TestServer_Sets(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server holding a set", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		added, err := rdb.SAdd(ctx, "set", "b", "a", "b").Result()
+		So(err, ShouldBeNil)
+		So(added, ShouldEqual, 2)
+
+		Convey("When the set is read", func() {
+			members, err := rdb.SMembers(ctx, "set").Result()
+			flags, flagsErr := rdb.SMIsMember(ctx, "set", "a", "c").Result()
+
+			Convey("Then its members are returned", func() {
+				So(err, ShouldBeNil)
+				So(members, ShouldResemble, []string{"a", "b"})
+				So(flagsErr, ShouldBeNil)
+				So(flags, ShouldResemble, []bool{true, false})
+				So(rdb.SIsMember(ctx, "set", "a").Val(), ShouldBeTrue)
+				So(rdb.SCard(ctx, "set").Val(), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When every member is removed", func() {
+			n, err := rdb.SRem(ctx, "set", "a", "b", "c").Result()
+
+			Convey("Then the set is deleted", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 2)
+				So(rdb.Exists(ctx, "set").Val(), ShouldEqual, 0)
+			})
+		})
+	})
+}
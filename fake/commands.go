@@ -0,0 +1,776 @@
+package fake
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// command is a redis command supported by the server
+type command struct {
+	// arity is the number of arguments including the command name, or the negated minimum if it takes a variable
+	// number of arguments
+	arity int
+	// run executes the command with its arguments, excluding the command name, and returns the reply. It is called
+	// with s.mu held.
+	run func(s *Server, args []string) any
+}
+
+// commands are the commands supported by the server, other than those handled by the connection such as MULTI
+var commands = map[string]command{
+	// connection
+	"ping":   {-1, cmdPing},
+	"echo":   {2, func(_ *Server, args []string) any { return args[0] }},
+	"select": {2, cmdSelect},
+
+	// keys
+	"del":       {-2, cmdDel},
+	"unlink":    {-2, cmdDel},
+	"exists":    {-2, cmdExists},
+	"type":      {2, cmdType},
+	"rename":    {3, cmdRename},
+	"expire":    {-3, expireCommand(time.Second, false)},
+	"pexpire":   {-3, expireCommand(time.Millisecond, false)},
+	"expireat":  {-3, expireCommand(time.Second, true)},
+	"pexpireat": {-3, expireCommand(time.Millisecond, true)},
+	"ttl":       {2, ttlCommand(time.Second)},
+	"pttl":      {2, ttlCommand(time.Millisecond)},
+	"persist":   {2, cmdPersist},
+	"scan":      {-2, cmdScan},
+	"keys":      {2, cmdKeys},
+	"dbsize":    {1, cmdDBSize},
+	"flushdb":   {-1, cmdFlush},
+	"flushall":  {-1, cmdFlush},
+
+	// strings
+	"get":         {2, cmdGet},
+	"set":         {-3, cmdSet},
+	"setnx":       {3, cmdSetNX},
+	"setex":       {4, setExCommand(time.Second)},
+	"psetex":      {4, setExCommand(time.Millisecond)},
+	"getex":       {-2, cmdGetEx},
+	"getdel":      {2, cmdGetDel},
+	"mget":        {-2, cmdMGet},
+	"mset":        {-3, cmdMSet},
+	"incr":        {2, func(s *Server, args []string) any { return incrBy(s, args[0], 1) }},
+	"decr":        {2, func(s *Server, args []string) any { return incrBy(s, args[0], -1) }},
+	"incrby":      {3, incrByCommand(1)},
+	"decrby":      {3, incrByCommand(-1)},
+	"incrbyfloat": {3, cmdIncrByFloat},
+	"append":      {3, cmdAppend},
+	"strlen":      {2, cmdStrlen},
+
+	// hashes
+	"hset":         {-4, cmdHSet},
+	"hmset":        {-4, cmdHMSet},
+	"hsetnx":       {4, cmdHSetNX},
+	"hget":         {3, cmdHGet},
+	"hmget":        {-3, cmdHMGet},
+	"hgetall":      {2, cmdHGetAll},
+	"hdel":         {-3, cmdHDel},
+	"hexists":      {3, cmdHExists},
+	"hlen":         {2, cmdHLen},
+	"hkeys":        {2, cmdHKeys},
+	"hvals":        {2, cmdHVals},
+	"hincrby":      {4, cmdHIncrBy},
+	"hincrbyfloat": {4, cmdHIncrByFloat},
+
+	// lists
+	"lpush":  {-3, pushCommand(true)},
+	"rpush":  {-3, pushCommand(false)},
+	"lpop":   {-2, popCommand(true)},
+	"rpop":   {-2, popCommand(false)},
+	"llen":   {2, cmdLLen},
+	"lrange": {4, cmdLRange},
+	"lindex": {3, cmdLIndex},
+	"lset":   {4, cmdLSet},
+	"ltrim":  {4, cmdLTrim},
+	"lrem":   {4, cmdLRem},
+
+	// sets
+	"sadd":       {-3, cmdSAdd},
+	"srem":       {-3, cmdSRem},
+	"smembers":   {2, cmdSMembers},
+	"sismember":  {3, cmdSIsMember},
+	"smismember": {-3, cmdSMIsMember},
+	"scard":      {2, cmdSCard},
+
+	// sorted sets
+	"zadd":             {-4, cmdZAdd},
+	"zincrby":          {4, cmdZIncrBy},
+	"zscore":           {3, cmdZScore},
+	"zcard":            {2, cmdZCard},
+	"zrem":             {-3, cmdZRem},
+	"zrank":            {3, rankCommand(false)},
+	"zrevrank":         {3, rankCommand(true)},
+	"zrange":           {-4, cmdZRange},
+	"zrevrange":        {-4, zrangeAlias("REV")},
+	"zrangebyscore":    {-4, zrangeAlias("BYSCORE")},
+	"zrevrangebyscore": {-4, zrangeAlias("BYSCORE", "REV")},
+	"zrangebylex":      {-4, zrangeAlias("BYLEX")},
+	"zrevrangebylex":   {-4, zrangeAlias("BYLEX", "REV")},
+}
+
+// execute runs a command sent by the client, queueing it instead if a transaction is in progress
+func (c *conn) execute(args []string) any {
+	name := strings.ToLower(args[0])
+
+	if reply, handled := c.clientCommand(name, args); handled {
+		return reply
+	}
+
+	switch name {
+	case "multi":
+		if c.multi {
+			return replyError("ERR MULTI calls can not be nested")
+		}
+		c.multi = true
+		return replyOK
+	case "exec":
+		if !c.multi {
+			return replyError("ERR EXEC without MULTI")
+		}
+		return c.exec()
+	case "discard":
+		if !c.multi {
+			return replyError("ERR DISCARD without MULTI")
+		}
+		c.reset()
+		return replyOK
+	case "watch":
+		if c.multi {
+			return replyError("ERR WATCH inside MULTI is not allowed")
+		}
+		if len(args) < 2 {
+			return wrongArity(name)
+		}
+		c.watch(args[1:])
+		return replyOK
+	case "unwatch":
+		c.watched = nil
+		return replyOK
+	}
+
+	// Commands that cannot be queued abort the transaction in progress
+	cmd, found := commands[name]
+	if !found {
+		c.aborted = c.aborted || c.multi
+		return replyError(fmt.Sprintf("ERR unknown command '%s', with args beginning with: %s", args[0], quoteArgs(args[1:])))
+	}
+
+	if (cmd.arity > 0 && len(args) != cmd.arity) || (cmd.arity < 0 && len(args) < -cmd.arity) {
+		c.aborted = c.aborted || c.multi
+		return wrongArity(name)
+	}
+
+	if c.multi {
+		c.queued = append(c.queued, args)
+		return status("QUEUED")
+	}
+
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+
+	reply := cmd.run(c.server, args[1:])
+	c.trackRead(name, args[1:])
+
+	return reply
+}
+
+// watch records the versions of keys, so that the next transaction fails if any of them are modified
+func (c *conn) watch(keys []string) {
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+
+	if c.watched == nil {
+		c.watched = make(map[string]uint64)
+	}
+
+	for _, key := range keys {
+		c.server.lookup(key)
+		if _, watching := c.watched[key]; !watching {
+			c.watched[key] = c.server.versions[key]
+		}
+	}
+}
+
+// exec runs the queued commands atomically, unless a watched key has been modified or a command could not be queued
+func (c *conn) exec() any {
+	defer c.reset()
+
+	if c.aborted {
+		return replyError("EXECABORT Transaction discarded because of previous errors.")
+	}
+
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+
+	for key, version := range c.watched {
+		c.server.lookup(key)
+		if c.server.versions[key] != version {
+			return nilArray{}
+		}
+	}
+
+	replies := make([]any, len(c.queued))
+	for i, args := range c.queued {
+		name := strings.ToLower(args[0])
+		replies[i] = commands[name].run(c.server, args[1:])
+		c.trackRead(name, args[1:])
+	}
+
+	return replies
+}
+
+// reset ends the transaction in progress and unwatches all keys
+func (c *conn) reset() {
+	c.multi = false
+	c.aborted = false
+	c.queued = nil
+	c.watched = nil
+}
+
+// wrongArity is the error returned when a command is sent with the wrong number of arguments
+func wrongArity(name string) replyError {
+	return replyError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", name))
+}
+
+// quoteArgs formats the arguments of an unknown command for its error message
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = "'" + arg + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+func cmdPing(_ *Server, args []string) any {
+	switch len(args) {
+	case 0:
+		return status("PONG")
+	case 1:
+		return args[0]
+	}
+	return wrongArity("ping")
+}
+
+func cmdSelect(_ *Server, args []string) any {
+	if args[0] != "0" {
+		return errDBOutOfRange
+	}
+	return replyOK
+}
+
+func cmdDel(s *Server, args []string) any {
+	var deleted int
+	for _, key := range args {
+		if s.lookup(key) != nil && s.remove(key) {
+			deleted++
+		}
+	}
+	return deleted
+}
+
+func cmdExists(s *Server, args []string) any {
+	var exists int
+	for _, key := range args {
+		if s.lookup(key) != nil {
+			exists++
+		}
+	}
+	return exists
+}
+
+func cmdType(s *Server, args []string) any {
+	e := s.lookup(args[0])
+	if e == nil {
+		return status("none")
+	}
+	return status(typeName(e.value))
+}
+
+func cmdRename(s *Server, args []string) any {
+	e := s.lookup(args[0])
+	if e == nil {
+		return errNoSuchKey
+	}
+
+	s.remove(args[0])
+	s.store(args[1], e.value, e.expiresAt)
+
+	return replyOK
+}
+
+// expireCommand returns the EXPIRE family command taking a TTL, or a unix time if at is true, in unit
+func expireCommand(unit time.Duration, at bool) func(s *Server, args []string) any {
+	return func(s *Server, args []string) any {
+		n, err := parseInt(args[1])
+		if err != nil {
+			return err
+		}
+
+		expiresAt := expiryTime(s, n, unit, at)
+
+		var nx, xx, gt, lt bool
+		for _, opt := range args[2:] {
+			switch strings.ToUpper(opt) {
+			case "NX":
+				nx = true
+			case "XX":
+				xx = true
+			case "GT":
+				gt = true
+			case "LT":
+				lt = true
+			default:
+				return replyError("ERR Unsupported option " + opt)
+			}
+		}
+
+		if (nx && (xx || gt || lt)) || (gt && lt) {
+			return replyError("ERR NX and XX, GT or LT options at the same time are not compatible")
+		}
+
+		e := s.lookup(args[0])
+		if e == nil {
+			return 0
+		}
+
+		// A key without a TTL is treated as having an infinite TTL by GT and LT
+		hasTTL := !e.expiresAt.IsZero()
+		if (nx && hasTTL) || (xx && !hasTTL) || (gt && (!hasTTL || !expiresAt.After(e.expiresAt))) ||
+			(lt && hasTTL && !expiresAt.Before(e.expiresAt)) {
+			return 0
+		}
+
+		e.expiresAt = expiresAt
+		s.touch(args[0])
+		s.lookup(args[0])
+
+		return 1
+	}
+}
+
+// ttlCommand returns the TTL command reporting the remaining TTL of a key in unit, -1 if it has no TTL or -2 if it
+// does not exist
+func ttlCommand(unit time.Duration) func(s *Server, args []string) any {
+	return func(s *Server, args []string) any {
+		e := s.lookup(args[0])
+		switch {
+		case e == nil:
+			return -2
+		case e.expiresAt.IsZero():
+			return -1
+		}
+
+		return int64((e.expiresAt.Sub(s.now) + unit/2) / unit)
+	}
+}
+
+func cmdPersist(s *Server, args []string) any {
+	e := s.lookup(args[0])
+	if e == nil || e.expiresAt.IsZero() {
+		return 0
+	}
+
+	e.expiresAt = time.Time{}
+	s.touch(args[0])
+
+	return 1
+}
+
+func cmdScan(s *Server, args []string) any {
+	cursor, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return errInvalidCursor
+	}
+
+	pattern, count, typ := "*", 10, ""
+	for i := 1; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			return errSyntax
+		}
+
+		switch strings.ToUpper(args[i]) {
+		case "MATCH":
+			pattern = args[i+1]
+		case "COUNT":
+			n, err := parseInt(args[i+1])
+			if err != nil {
+				return err
+			}
+			if n < 1 {
+				return errSyntax
+			}
+			count = int(n)
+		case "TYPE":
+			typ = strings.ToLower(args[i+1])
+		default:
+			return errSyntax
+		}
+	}
+
+	// As in redis, COUNT limits the keys examined rather than the keys returned, so pages may be empty
+	page, next := s.scanKeys(cursor, count)
+
+	keys := make([]string, 0, len(page))
+	for _, key := range page {
+		if match(pattern, key) && (typ == "" || typeName(s.keys[key].value) == typ) {
+			keys = append(keys, key)
+		}
+	}
+
+	return []any{strconv.FormatUint(next, 10), keys}
+}
+
+func cmdKeys(s *Server, args []string) any {
+	keys := make([]string, 0)
+	for _, key := range s.liveKeys() {
+		if match(args[0], key) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func cmdDBSize(s *Server, _ []string) any {
+	return len(s.liveKeys())
+}
+
+func cmdFlush(s *Server, args []string) any {
+	if len(args) > 1 {
+		return errSyntax
+	}
+
+	s.flush()
+
+	return replyOK
+}
+
+func cmdGet(s *Server, args []string) any {
+	value, exists, err := lookupValue[string](s, args[0])
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	return value
+}
+
+// expiryOption parses the EX, PX, EXAT or PXAT option of name at args[i], returning the expiry time it sets and
+// whether args[i] is such an option
+func expiryOption(s *Server, name string, args []string, i int) (time.Time, bool, error) {
+	var unit time.Duration
+	var at bool
+
+	switch strings.ToUpper(args[i]) {
+	case "EX":
+		unit = time.Second
+	case "PX":
+		unit = time.Millisecond
+	case "EXAT":
+		unit, at = time.Second, true
+	case "PXAT":
+		unit, at = time.Millisecond, true
+	default:
+		return time.Time{}, false, nil
+	}
+
+	if i+1 >= len(args) {
+		return time.Time{}, true, errSyntax
+	}
+
+	n, err := parseInt(args[i+1])
+	if err != nil {
+		return time.Time{}, true, err
+	}
+
+	if n <= 0 {
+		return time.Time{}, true, replyError(fmt.Sprintf("ERR invalid expire time in '%s' command", name))
+	}
+
+	return expiryTime(s, n, unit, at), true, nil
+}
+
+// expiryTime returns the time a key expires given a TTL of n units, or a unix time of n units if at is true
+func expiryTime(s *Server, n int64, unit time.Duration, at bool) time.Time {
+	switch {
+	case at && unit == time.Second:
+		return time.Unix(n, 0)
+	case at:
+		return time.UnixMilli(n)
+	}
+
+	return s.now.Add(time.Duration(n) * unit)
+}
+
+func cmdSet(s *Server, args []string) any {
+	key, value := args[0], args[1]
+
+	var nx, xx, keepTTL, get bool
+	var expiresAt time.Time
+
+	for i := 2; i < len(args); i++ {
+		expiry, isExpiry, err := expiryOption(s, "set", args, i)
+		switch {
+		case err != nil:
+			return err
+		case isExpiry:
+			if !expiresAt.IsZero() || keepTTL {
+				return errSyntax
+			}
+			expiresAt = expiry
+			i++
+			continue
+		}
+
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "KEEPTTL":
+			keepTTL = true
+		case "GET":
+			get = true
+		default:
+			return errSyntax
+		}
+	}
+
+	if (nx && xx) || (keepTTL && !expiresAt.IsZero()) {
+		return errSyntax
+	}
+
+	e := s.lookup(key)
+
+	var previous any
+	if get && e != nil {
+		str, isString := e.value.(string)
+		if !isString {
+			return errWrongType
+		}
+		previous = str
+	}
+
+	if (nx && e != nil) || (xx && e == nil) {
+		return previous
+	}
+
+	if keepTTL && e != nil {
+		expiresAt = e.expiresAt
+	}
+
+	s.store(key, value, expiresAt)
+
+	if get {
+		return previous
+	}
+
+	return replyOK
+}
+
+func cmdSetNX(s *Server, args []string) any {
+	if s.lookup(args[0]) != nil {
+		return 0
+	}
+
+	s.store(args[0], args[1], time.Time{})
+
+	return 1
+}
+
+// setExCommand returns the SETEX command setting a value with a TTL in unit
+func setExCommand(unit time.Duration) func(s *Server, args []string) any {
+	return func(s *Server, args []string) any {
+		n, err := parseInt(args[1])
+		if err != nil {
+			return err
+		}
+
+		if n <= 0 {
+			return replyError("ERR invalid expire time in 'setex' command")
+		}
+
+		s.store(args[0], args[2], s.now.Add(time.Duration(n)*unit))
+
+		return replyOK
+	}
+}
+
+func cmdGetEx(s *Server, args []string) any {
+	var expiresAt time.Time
+	var persist, changeTTL bool
+
+	for i := 1; i < len(args); i++ {
+		expiry, isExpiry, err := expiryOption(s, "getex", args, i)
+		switch {
+		case err != nil:
+			return err
+		case isExpiry && !changeTTL:
+			expiresAt, changeTTL = expiry, true
+			i++
+		case strings.EqualFold(args[i], "PERSIST") && !changeTTL:
+			persist, changeTTL = true, true
+		default:
+			return errSyntax
+		}
+	}
+
+	value, exists, err := lookupValue[string](s, args[0])
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	if changeTTL {
+		e := s.keys[args[0]]
+		e.expiresAt = expiresAt
+		if persist {
+			e.expiresAt = time.Time{}
+		}
+		s.touch(args[0])
+		s.lookup(args[0])
+	}
+
+	return value
+}
+
+func cmdGetDel(s *Server, args []string) any {
+	value, exists, err := lookupValue[string](s, args[0])
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	s.remove(args[0])
+
+	return value
+}
+
+func cmdMGet(s *Server, args []string) any {
+	values := make([]any, len(args))
+	for i, key := range args {
+		// Keys holding other types are returned as nil rather than failing the command
+		if value, exists, err := lookupValue[string](s, key); err == nil && exists {
+			values[i] = value
+		}
+	}
+	return values
+}
+
+func cmdMSet(s *Server, args []string) any {
+	if len(args)%2 != 0 {
+		return wrongArity("mset")
+	}
+
+	for i := 0; i < len(args); i += 2 {
+		s.store(args[i], args[i+1], time.Time{})
+	}
+
+	return replyOK
+}
+
+// incrByCommand returns the INCRBY command, which decrements if sign is negative
+func incrByCommand(sign int64) func(s *Server, args []string) any {
+	return func(s *Server, args []string) any {
+		n, err := parseInt(args[1])
+		if err != nil {
+			return err
+		}
+		return incrBy(s, args[0], sign*n)
+	}
+}
+
+// incrBy adds n to the integer value of key, keeping its TTL
+func incrBy(s *Server, key string, n int64) any {
+	value, exists, err := lookupValue[string](s, key)
+	if err != nil {
+		return err
+	}
+
+	var current int64
+	if exists {
+		if current, err = parseInt(value); err != nil {
+			return err
+		}
+	}
+
+	if (n > 0 && current > math.MaxInt64-n) || (n < 0 && current < math.MinInt64-n) {
+		return replyError("ERR increment or decrement would overflow")
+	}
+
+	current += n
+	storeKeepTTL(s, key, strconv.FormatInt(current, 10))
+
+	return current
+}
+
+func cmdIncrByFloat(s *Server, args []string) any {
+	n, err := parseFloat(args[1])
+	if err != nil {
+		return err
+	}
+
+	value, exists, err := lookupValue[string](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	var current float64
+	if exists {
+		if current, err = parseFloat(value); err != nil {
+			return err
+		}
+	}
+
+	result, err := addFloat(current, n)
+	if err != nil {
+		return err
+	}
+
+	storeKeepTTL(s, args[0], formatFloat(result))
+
+	return result
+}
+
+func cmdAppend(s *Server, args []string) any {
+	value, _, err := lookupValue[string](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	value += args[1]
+	storeKeepTTL(s, args[0], value)
+
+	return len(value)
+}
+
+func cmdStrlen(s *Server, args []string) any {
+	value, _, err := lookupValue[string](s, args[0])
+	if err != nil {
+		return err
+	}
+	return len(value)
+}
+
+// storeKeepTTL sets the value of key, keeping its TTL if it exists
+func storeKeepTTL(s *Server, key string, value any) {
+	var expiresAt time.Time
+	if e := s.lookup(key); e != nil {
+		expiresAt = e.expiresAt
+	}
+
+	s.store(key, value, expiresAt)
+}
@@ -0,0 +1,180 @@
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestServer_Strings(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server holding a string with a TTL", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		So(rdb.Set(ctx, "key", "value", time.Minute).Err(), ShouldBeNil)
+
+		Convey("When a value is set only if the key does not exist", func() {
+			existing, err := rdb.SetNX(ctx, "key", "other", 0).Result()
+			missing, missingErr := rdb.SetNX(ctx, "new", "other", time.Second).Result()
+
+			Convey("Then only the missing key is set", func() {
+				So(err, ShouldBeNil)
+				So(existing, ShouldBeFalse)
+				So(missingErr, ShouldBeNil)
+				So(missing, ShouldBeTrue)
+				So(rdb.Get(ctx, "key").Val(), ShouldEqual, "value")
+				So(rdb.Get(ctx, "new").Val(), ShouldEqual, "other")
+			})
+		})
+
+		Convey("When a value is set only if the key exists, keeping its TTL", func() {
+			err := rdb.SetArgs(ctx, "key", "updated", redis.SetArgs{Mode: "XX", KeepTTL: true}).Err()
+
+			Convey("Then the value is replaced and the TTL kept", func() {
+				So(err, ShouldBeNil)
+				So(rdb.Get(ctx, "key").Val(), ShouldEqual, "updated")
+				So(rdb.TTL(ctx, "key").Val(), ShouldEqual, time.Minute)
+			})
+		})
+
+		Convey("When a value is replaced and the old value returned", func() {
+			old, err := rdb.SetArgs(ctx, "key", "updated", redis.SetArgs{Get: true}).Result()
+
+			Convey("Then the old value is returned and the TTL removed", func() {
+				So(err, ShouldBeNil)
+				So(old, ShouldEqual, "value")
+				So(rdb.TTL(ctx, "key").Val(), ShouldEqual, -1)
+			})
+		})
+
+		Convey("When a value is read and its TTL changed", func() {
+			val, err := rdb.GetEx(ctx, "key", time.Hour).Result()
+
+			Convey("Then the value is returned with the new TTL", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "value")
+				So(rdb.TTL(ctx, "key").Val(), ShouldEqual, time.Hour)
+			})
+		})
+
+		Convey("When several values are read, including a missing key and a hash", func() {
+			So(rdb.HSet(ctx, "hash", "field", "value").Err(), ShouldBeNil)
+			vals, err := rdb.MGet(ctx, "key", "missing", "hash").Result()
+
+			Convey("Then missing and non-string keys are nil", func() {
+				So(err, ShouldBeNil)
+				So(vals, ShouldResemble, []interface{}{"value", nil, nil})
+			})
+		})
+
+		Convey("When a counter is incremented", func() {
+			n, err := rdb.IncrBy(ctx, "counter", 5).Result()
+			f, floatErr := rdb.IncrByFloat(ctx, "counter", 0.5).Result()
+			_, wrongErr := rdb.Incr(ctx, "key").Result()
+
+			Convey("Then the new values are returned", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 5)
+				So(floatErr, ShouldBeNil)
+				So(f, ShouldEqual, 5.5)
+				So(wrongErr.Error(), ShouldStartWith, "ERR value is not an integer")
+			})
+		})
+
+		Convey("When a string is read as a hash", func() {
+			err := rdb.HGet(ctx, "key", "field").Err()
+
+			Convey("Then a WRONGTYPE error is returned", func() {
+				So(redis.HasErrorPrefix(err, "WRONGTYPE"), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestServer_Keys(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server holding keys with and without TTLs", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		So(rdb.Set(ctx, "persistent", "value", 0).Err(), ShouldBeNil)
+		So(rdb.Set(ctx, "expiring", "value", time.Minute).Err(), ShouldBeNil)
+
+		Convey("When expiries are set conditionally", func() {
+			nx := rdb.ExpireNX(ctx, "expiring", time.Hour).Val()
+			gt := rdb.ExpireGT(ctx, "expiring", time.Hour).Val()
+			lt := rdb.ExpireLT(ctx, "expiring", time.Second).Val()
+			xx := rdb.ExpireXX(ctx, "persistent", time.Hour).Val()
+
+			Convey("Then they are only set when their condition holds", func() {
+				So(nx, ShouldBeFalse)
+				So(gt, ShouldBeTrue)
+				So(lt, ShouldBeTrue)
+				So(xx, ShouldBeFalse)
+				So(rdb.TTL(ctx, "expiring").Val(), ShouldEqual, time.Second)
+				So(rdb.TTL(ctx, "persistent").Val(), ShouldEqual, -1)
+			})
+		})
+
+		Convey("When a TTL is removed", func() {
+			persisted := rdb.Persist(ctx, "expiring").Val()
+			srv.Advance(time.Hour)
+
+			Convey("Then the key no longer expires", func() {
+				So(persisted, ShouldBeTrue)
+				So(rdb.Exists(ctx, "expiring").Val(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When a key is renamed", func() {
+			err := rdb.Rename(ctx, "expiring", "renamed").Err()
+
+			Convey("Then it keeps its value and TTL", func() {
+				So(err, ShouldBeNil)
+				So(rdb.Exists(ctx, "expiring").Val(), ShouldEqual, 0)
+				So(rdb.Get(ctx, "renamed").Val(), ShouldEqual, "value")
+				So(rdb.TTL(ctx, "renamed").Val(), ShouldEqual, time.Minute)
+			})
+		})
+
+		Convey("When keys are deleted", func() {
+			n, err := rdb.Del(ctx, "persistent", "expiring", "missing").Result()
+
+			Convey("Then the number of deleted keys is returned", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 2)
+				So(rdb.DBSize(ctx).Val(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the types of keys are read", func() {
+			So(rdb.SAdd(ctx, "set", "member").Err(), ShouldBeNil)
+
+			Convey("Then each key's type is returned", func() {
+				So(rdb.Type(ctx, "persistent").Val(), ShouldEqual, "string")
+				So(rdb.Type(ctx, "set").Val(), ShouldEqual, "set")
+				So(rdb.Type(ctx, "missing").Val(), ShouldEqual, "none")
+			})
+		})
+
+		Convey("When an unsupported command is sent", func() {
+			err := rdb.XAdd(ctx, &redis.XAddArgs{Stream: "stream", Values: []string{"a", "b"}}).Err()
+
+			Convey("Then it fails with an unknown command error", func() {
+				So(err.Error(), ShouldStartWith, "ERR unknown command")
+			})
+		})
+	})
+}
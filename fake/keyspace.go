@@ -0,0 +1,289 @@
+package fake
+
+import (
+	"hash/fnv"
+	"sort"
+	"time"
+)
+
+// entry is a key's value and expiry. The value is a string, hash, *list, set or sortedSet.
+type entry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// hash is the value of a hash key
+type hash map[string]string
+
+// list is the value of a list key
+type list struct {
+	items []string
+}
+
+// set is the value of a set key
+type set map[string]struct{}
+
+// sortedSet is the value of a sorted set key, holding the score of each member
+type sortedSet map[string]float64
+
+// typeName returns the name of the type of value reported by TYPE
+func typeName(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case hash:
+		return "hash"
+	case *list:
+		return "list"
+	case set:
+		return "set"
+	case sortedSet:
+		return "zset"
+	}
+
+	return "none"
+}
+
+// expired reports whether the entry's TTL has passed at now
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// lookup returns the entry of key, or nil if the key does not exist or has expired. The caller must hold s.mu.
+func (s *Server) lookup(key string) *entry {
+	e, ok := s.keys[key]
+	if !ok {
+		return nil
+	}
+
+	if e.expired(s.now) {
+		s.remove(key)
+		return nil
+	}
+
+	return e
+}
+
+// store sets the value of key, replacing any existing value and TTL. The caller must hold s.mu.
+func (s *Server) store(key string, value any, expiresAt time.Time) {
+	s.keys[key] = &entry{value: value, expiresAt: expiresAt}
+	s.touch(key)
+}
+
+// remove deletes key, reporting whether it existed. The caller must hold s.mu.
+func (s *Server) remove(key string) bool {
+	if _, ok := s.keys[key]; !ok {
+		return false
+	}
+
+	delete(s.keys, key)
+	s.touch(key)
+
+	return true
+}
+
+// touch marks key as modified, failing transactions watching it and invalidating it for clients tracking it.
+// The caller must hold s.mu.
+func (s *Server) touch(key string) {
+	s.version++
+	s.versions[key] = s.version
+	s.invalidate(key)
+}
+
+// flush deletes every key, sending a single invalidation for all keys to tracking clients. The caller must hold s.mu.
+func (s *Server) flush() {
+	for key := range s.keys {
+		delete(s.keys, key)
+		s.version++
+		s.versions[key] = s.version
+	}
+
+	s.invalidateAll()
+}
+
+// liveKeys returns the keys that have not expired. The caller must hold s.mu.
+func (s *Server) liveKeys() []string {
+	keys := make([]string, 0, len(s.keys))
+	for key := range s.keys {
+		if s.lookup(key) != nil {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// lookupValue returns the value of key if it exists and holds a T, or errWrongType if it holds another type. The
+// caller must hold s.mu.
+func lookupValue[T any](s *Server, key string) (value T, exists bool, err error) {
+	e := s.lookup(key)
+	if e == nil {
+		return value, false, nil
+	}
+
+	value, ok := e.value.(T)
+	if !ok {
+		return value, false, errWrongType
+	}
+
+	return value, true, nil
+}
+
+// lookupOrCreate returns the value of key, storing the value returned by create if the key does not exist. The
+// caller must hold s.mu, and must call touch or removeIfEmpty once the value has been modified.
+func lookupOrCreate[T any](s *Server, key string, create func() T) (T, error) {
+	value, exists, err := lookupValue[T](s, key)
+	if err != nil || exists {
+		return value, err
+	}
+
+	value = create()
+	s.store(key, value, time.Time{})
+
+	return value, nil
+}
+
+// removeIfEmpty deletes key if its collection has no elements left, as redis does, or otherwise marks it as
+// modified. The caller must hold s.mu.
+func (s *Server) removeIfEmpty(key string, size int) {
+	if size == 0 {
+		s.remove(key)
+		return
+	}
+
+	s.touch(key)
+}
+
+// scanPosition is the position of key in the order keys are scanned. The order is fixed by hashing the keys, so
+// that keys present for a whole scan are returned even if other keys are added or removed during it.
+func scanPosition(key string) uint64 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return uint64(h.Sum32()) + 1
+}
+
+// scanKeys returns up to count keys from cursor in scan order, and the cursor of the next page, or 0 if the scan is
+// complete. Keys with the same position are returned together, so a page may hold more than count keys. The caller
+// must hold s.mu.
+func (s *Server) scanKeys(cursor uint64, count int) ([]string, uint64) {
+	keys := s.liveKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		pi, pj := scanPosition(keys[i]), scanPosition(keys[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return keys[i] < keys[j]
+	})
+
+	start := sort.Search(len(keys), func(i int) bool {
+		return scanPosition(keys[i]) >= cursor
+	})
+
+	end := min(start+count, len(keys))
+	for end < len(keys) && end > start && scanPosition(keys[end]) == scanPosition(keys[end-1]) {
+		end++
+	}
+
+	if end == len(keys) {
+		return keys[start:end], 0
+	}
+
+	return keys[start:end], scanPosition(keys[end])
+}
+
+// match reports whether s matches the glob-style pattern used by SCAN and KEYS, which supports *, ?, [abc], [^abc],
+// [a-z] and escaping with \
+func match(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if match(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+		case '[':
+			if len(s) == 0 {
+				return false
+			}
+
+			var matched bool
+			matched, pattern = matchClass(pattern[1:], s[0])
+			if !matched {
+				return false
+			}
+
+			s = s[1:]
+			continue
+		default:
+			if pattern[0] == '\\' && len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			if len(s) == 0 || pattern[0] != s[0] {
+				return false
+			}
+		}
+
+		pattern = pattern[1:]
+		s = s[1:]
+	}
+
+	return len(s) == 0
+}
+
+// matchClass reports whether c matches the character class at the start of pattern, after the opening [, and
+// returns the rest of the pattern after the closing ]
+func matchClass(pattern string, c byte) (bool, string) {
+	negate := len(pattern) > 0 && pattern[0] == '^'
+	if negate {
+		pattern = pattern[1:]
+	}
+
+	matched := false
+	for len(pattern) > 0 && pattern[0] != ']' {
+		switch {
+		case pattern[0] == '\\' && len(pattern) > 1:
+			matched = matched || pattern[1] == c
+			pattern = pattern[2:]
+		case len(pattern) > 2 && pattern[1] == '-' && pattern[2] != ']':
+			lo, hi := pattern[0], pattern[2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			matched = matched || (c >= lo && c <= hi)
+			pattern = pattern[3:]
+		default:
+			matched = matched || pattern[0] == c
+			pattern = pattern[1:]
+		}
+	}
+
+	if len(pattern) > 0 {
+		pattern = pattern[1:]
+	}
+
+	return matched != negate, pattern
+}
+
+// sortedKeys returns the keys of a hash or set in order, so that replies listing them are deterministic
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,139 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMatch(t *testing.T) {
+	Convey("Given glob-style patterns", t, func() {
+		cases := []struct {
+			pattern string
+			key     string
+			matches bool
+		}{
+			{"*", "", true},
+			{"*", "anything", true},
+			{"user:*", "user:1", true},
+			{"user:*", "order:1", false},
+			{"*:1", "user:1", true},
+			{"user:?", "user:1", true},
+			{"user:?", "user:10", false},
+			{"user:[12]", "user:2", true},
+			{"user:[12]", "user:3", false},
+			{"user:[^12]", "user:3", true},
+			{"user:[a-c]", "user:b", true},
+			{"user:[a-c]", "user:d", false},
+			{`user:\*`, "user:*", true},
+			{`user:\*`, "user:1", false},
+			{"a*b*c", "aXXbYYc", true},
+			{"a*b*c", "aXXbYY", false},
+		}
+
+		for _, c := range cases {
+			Convey(fmt.Sprintf("Then %q matching %q is %v", c.pattern, c.key, c.matches), func() {
+				So(match(c.pattern, c.key), ShouldEqual, c.matches)
+			})
+		}
+	})
+}
+
+func TestServer_Scan(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server holding keys of several types", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		for i := range 50 {
+			So(rdb.Set(ctx, fmt.Sprintf("user:%d", i), i, 0).Err(), ShouldBeNil)
+		}
+		So(rdb.HSet(ctx, "user:hash", "field", "value").Err(), ShouldBeNil)
+		So(rdb.Set(ctx, "order:1", "1", 0).Err(), ShouldBeNil)
+
+		scanAll := func(match string, count int64, typ string, during func()) ([]string, int) {
+			var keys []string
+			var pages int
+			var cursor uint64
+			for {
+				page, next, err := rdb.ScanType(ctx, cursor, match, count, typ).Result()
+				So(err, ShouldBeNil)
+				keys = append(keys, page...)
+				pages++
+				if during != nil {
+					during()
+					during = nil
+				}
+				if next == 0 {
+					return keys, pages
+				}
+				cursor = next
+			}
+		}
+
+		Convey("When every key matching a pattern is scanned in pages", func() {
+			keys, pages := scanAll("user:*", 10, "", nil)
+
+			Convey("Then each matching key is returned once over several pages", func() {
+				So(keys, ShouldHaveLength, 51)
+				So(pages, ShouldBeGreaterThan, 1)
+				So(keys, ShouldNotContain, "order:1")
+			})
+		})
+
+		Convey("When keys are scanned by type", func() {
+			keys, _ := scanAll("*", 100, "hash", nil)
+
+			Convey("Then only keys of that type are returned", func() {
+				So(keys, ShouldResemble, []string{"user:hash"})
+			})
+		})
+
+		Convey("When keys are added and removed during a scan", func() {
+			keys, _ := scanAll("user:*", 10, "", func() {
+				for i := range 50 {
+					So(rdb.Set(ctx, fmt.Sprintf("user:new:%d", i), i, 0).Err(), ShouldBeNil)
+				}
+				So(rdb.Del(ctx, "order:1").Err(), ShouldBeNil)
+			})
+
+			Convey("Then every key present for the whole scan is returned", func() {
+				for i := range 50 {
+					So(keys, ShouldContain, fmt.Sprintf("user:%d", i))
+				}
+			})
+		})
+
+		Convey("When a scan is made with an invalid cursor", func() {
+			err := rdb.Do(ctx, "scan", "invalid").Err()
+
+			Convey("Then it fails", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "ERR invalid cursor")
+			})
+		})
+
+		Convey("When the keys are listed", func() {
+			keys, err := rdb.Keys(ctx, "order:*").Result()
+
+			Convey("Then the matching keys are returned", func() {
+				So(err, ShouldBeNil)
+				So(keys, ShouldResemble, []string{"order:1"})
+			})
+		})
+
+		Convey("When every key is flushed", func() {
+			srv.FlushAll()
+
+			Convey("Then no keys remain", func() {
+				So(rdb.DBSize(ctx).Val(), ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,198 @@
+package fake
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// status is a simple string reply, such as OK
+type status string
+
+// replyError is an error reply
+type replyError string
+
+func (e replyError) Error() string {
+	return string(e)
+}
+
+// nilArray is the null array reply, sent for example by EXEC when a watched key was modified
+type nilArray struct{}
+
+// protocolError is a malformed request sent by a client
+type protocolError string
+
+func (e protocolError) Error() string {
+	return string(e)
+}
+
+var (
+	replyOK           = status("OK")
+	errWrongType      = replyError("WRONGTYPE Operation against a key holding the wrong kind of value")
+	errSyntax         = replyError("ERR syntax error")
+	errNotInteger     = replyError("ERR value is not an integer or out of range")
+	errNotFloat       = replyError("ERR value is not a valid float")
+	errNoSuchKey      = replyError("ERR no such key")
+	errOutOfRange     = replyError("ERR index out of range")
+	errInvalidCursor  = replyError("ERR invalid cursor")
+	errIncrementNaN   = replyError("ERR increment would produce NaN or Infinity")
+	errDBOutOfRange   = replyError("ERR DB index is out of range")
+	errMinMaxNotFloat = replyError("ERR min or max is not a float")
+	errMinMaxNotLex   = replyError("ERR min or max not valid string range item")
+)
+
+// readCommand reads a command sent by a client as an array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	n, err := readLength(r, '*')
+	if err != nil {
+		return nil, err
+	}
+
+	if n <= 0 {
+		return nil, protocolError("expected a command")
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		size, err := readLength(r, '$')
+		if err != nil {
+			return nil, err
+		}
+
+		if size < 0 {
+			return nil, protocolError("invalid bulk length")
+		}
+
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+
+		if !bytes.HasSuffix(buf, []byte("\r\n")) {
+			return nil, protocolError("expected '\\r\\n' after bulk string")
+		}
+
+		args[i] = string(buf[:size])
+	}
+
+	return args, nil
+}
+
+// readLength reads a line holding the length of an array or bulk string, marked with prefix
+func readLength(r *bufio.Reader, prefix byte) (int, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+
+	if len(line) < 3 || line[0] != prefix || line[len(line)-2] != '\r' {
+		return 0, protocolError(fmt.Sprintf("expected '%c', got '%q'", prefix, line))
+	}
+
+	n, err := strconv.Atoi(line[1 : len(line)-2])
+	if err != nil {
+		return 0, protocolError("invalid length")
+	}
+
+	return n, nil
+}
+
+// writeReply writes a reply to buf in RESP2, the protocol used by clients of the server
+func writeReply(buf *bytes.Buffer, reply any) {
+	switch reply := reply.(type) {
+	case nil:
+		buf.WriteString("$-1\r\n")
+	case status:
+		buf.WriteString("+" + string(reply) + "\r\n")
+	case error:
+		buf.WriteString("-" + reply.Error() + "\r\n")
+	case int:
+		buf.WriteString(":" + strconv.Itoa(reply) + "\r\n")
+	case int64:
+		buf.WriteString(":" + strconv.FormatInt(reply, 10) + "\r\n")
+	case bool:
+		if reply {
+			buf.WriteString(":1\r\n")
+		} else {
+			buf.WriteString(":0\r\n")
+		}
+	case float64:
+		writeReply(buf, formatFloat(reply))
+	case string:
+		buf.WriteString("$" + strconv.Itoa(len(reply)) + "\r\n" + reply + "\r\n")
+	case []string:
+		buf.WriteString("*" + strconv.Itoa(len(reply)) + "\r\n")
+		for _, elem := range reply {
+			writeReply(buf, elem)
+		}
+	case []any:
+		buf.WriteString("*" + strconv.Itoa(len(reply)) + "\r\n")
+		for _, elem := range reply {
+			writeReply(buf, elem)
+		}
+	case nilArray:
+		buf.WriteString("*-1\r\n")
+	default:
+		panic(fmt.Sprintf("fake: unsupported reply type %T", reply))
+	}
+}
+
+// formatFloat formats f as redis does in replies
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// parseInt parses an integer argument
+func parseInt(arg string) (int64, error) {
+	n, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, errNotInteger
+	}
+
+	return n, nil
+}
+
+// parseRange parses the start and stop indexes of a range
+func parseRange(start, stop string) (int64, int64, error) {
+	first, err := parseInt(start)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	last, err := parseInt(stop)
+	return first, last, err
+}
+
+// parseFloat parses a float argument, which may be inf, +inf or -inf
+func parseFloat(arg string) (float64, error) {
+	f, err := strconv.ParseFloat(arg, 64)
+	if err != nil || math.IsNaN(f) {
+		return 0, errNotFloat
+	}
+
+	return f, nil
+}
+
+// isError reports whether a reply is an error reply
+func isError(reply any) bool {
+	_, isErr := reply.(error)
+	return isErr
+}
+
+// replyOrZero returns err as the reply if it is not nil, or 0 for commands on keys that do not exist
+func replyOrZero(err error) any {
+	if err != nil {
+		return err
+	}
+	return 0
+}
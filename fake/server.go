@@ -0,0 +1,252 @@
+// Package fake provides an in-memory redis server for unit testing code that uses dis-redis or go-redis, without
+// running redis.
+//
+// A Server speaks the redis protocol to go-redis clients over in-memory connections, so commands, pipelines and
+// transactions behave as they do against redis:
+//
+//	srv := fake.NewServer()
+//	defer srv.Close()
+//
+//	cli := disRedis.NewClientWithCustomClient(ctx, &disRedis.ClientConfig{}, srv.NewClient())
+//
+// The server supports strings, hashes, lists, sets and sorted sets, key expiry, SCAN with cursors and MATCH patterns,
+// MULTI/EXEC transactions with WATCH, and client side caching with CLIENT TRACKING, whose invalidations are published
+// to the __redis__:invalidate channel of the client they are redirected to. Time stands still unless it is moved with
+// Advance or SetTime, so TTLs can be tested without sleeping. Other commands, such as scripts, streams and publishing
+// to channels, fail with an unknown command error.
+package fake
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// address is the address reported by clients of the fake server
+const address = "fake-redis:6379"
+
+// ErrServerClosed is returned when a client connects to a server that has been closed.
+var ErrServerClosed = errors.New("fake redis server closed")
+
+// Server is an in-memory redis server. It is safe for concurrent use by multiple clients.
+type Server struct {
+	mu       sync.Mutex
+	now      time.Time
+	keys     map[string]*entry
+	versions map[string]uint64
+	version  uint64
+	conns    map[*conn]struct{}
+	clients  map[int64]*conn
+	lastID   int64
+	tracked  map[string]map[int64]struct{}
+	closed   bool
+}
+
+// NewServer returns an empty server whose clock is set to the current time.
+func NewServer() *Server {
+	return &Server{
+		now:      time.Now(),
+		keys:     make(map[string]*entry),
+		versions: make(map[string]uint64),
+		conns:    make(map[*conn]struct{}),
+		clients:  make(map[int64]*conn),
+		tracked:  make(map[string]map[int64]struct{}),
+	}
+}
+
+// NewClient returns a go-redis client connected to the server, which can be passed to NewClientWithCustomClient.
+// Clients must be closed by the caller.
+func (s *Server) NewClient() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:            address,
+		Protocol:        2,
+		DisableIdentity: true,
+		Dialer:          s.dial,
+	})
+}
+
+// Now returns the time on the server's clock.
+func (s *Server) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.now
+}
+
+// SetTime sets the server's clock to t, expiring any keys whose TTL has passed.
+func (s *Server) SetTime(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = t
+}
+
+// Advance moves the server's clock forward by d, expiring any keys whose TTL has passed.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = s.now.Add(d)
+}
+
+// FlushAll deletes every key.
+func (s *Server) FlushAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.flush()
+}
+
+// Close disconnects every client and refuses new connections. Commands sent by clients of a closed server fail with
+// connection errors.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.DisconnectClients()
+
+	return nil
+}
+
+// DisconnectClients closes every client connection without closing the server, as if the network had failed.
+// A command sent on a disconnected connection fails, and clients reconnect for the commands after it.
+func (s *Server) DisconnectClients() {
+	s.mu.Lock()
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		c.netConn.Close()
+	}
+}
+
+// dial connects a client to the server
+func (s *Server) dial(_ context.Context, _, _ string) (net.Conn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrServerClosed
+	}
+
+	clientConn, serverConn := net.Pipe()
+
+	s.lastID++
+	c := newConn(s, s.lastID, serverConn)
+	s.conns[c] = struct{}{}
+	s.clients[c.id] = c
+
+	go c.serve()
+
+	return clientConn, nil
+}
+
+// disconnect forgets a closed connection
+func (s *Server) disconnect(c *conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.conns, c)
+	delete(s.clients, c.id)
+}
+
+// conn is a client connection to the server, holding the state of any transaction in progress
+type conn struct {
+	server  *Server
+	id      int64
+	netConn net.Conn
+
+	// tracking and subscribed are guarded by server.mu, as they are used when other clients modify keys
+	tracking   *tracking
+	subscribed map[string]struct{}
+
+	multi   bool
+	aborted bool
+	queued  [][]string
+	watched map[string]uint64
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending bytes.Buffer
+	done    bool
+}
+
+func newConn(s *Server, id int64, netConn net.Conn) *conn {
+	c := &conn{server: s, id: id, netConn: netConn}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// serve reads and executes commands until the connection is closed. Replies are written by a separate goroutine, so
+// that clients can send pipelines of any size before reading the replies.
+func (c *conn) serve() {
+	defer c.server.disconnect(c)
+	defer c.close()
+
+	go c.writeReplies()
+
+	r := bufio.NewReader(c.netConn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			var protoErr protocolError
+			if errors.As(err, &protoErr) {
+				c.reply(replyError("ERR Protocol error: " + protoErr.Error()))
+			}
+			return
+		}
+
+		c.reply(c.execute(args))
+	}
+}
+
+// reply queues a reply to be written to the client
+func (c *conn) reply(reply any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeReply(&c.pending, reply)
+	c.cond.Signal()
+}
+
+// writeReplies writes queued replies to the client until the connection is closed, then closes it
+func (c *conn) writeReplies() {
+	defer c.netConn.Close()
+
+	for {
+		c.mu.Lock()
+		for c.pending.Len() == 0 && !c.done {
+			c.cond.Wait()
+		}
+		if c.pending.Len() == 0 {
+			c.mu.Unlock()
+			return
+		}
+		data := bytes.Clone(c.pending.Bytes())
+		c.pending.Reset()
+		c.mu.Unlock()
+
+		if _, err := c.netConn.Write(data); err != nil {
+			return
+		}
+	}
+}
+
+// close closes the connection once the queued replies have been written
+func (c *conn) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done = true
+	c.cond.Signal()
+}
@@ -0,0 +1,295 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	disRedis "github.com/ONSdigital/dis-redis"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestServer_Clock(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server with a key that expires in a minute", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		So(rdb.Set(ctx, "key", "value", time.Minute).Err(), ShouldBeNil)
+
+		Convey("When the clock has not moved", func() {
+			ttl, err := rdb.TTL(ctx, "key").Result()
+
+			Convey("Then the key has its full TTL", func() {
+				So(err, ShouldBeNil)
+				So(ttl, ShouldEqual, time.Minute)
+			})
+		})
+
+		Convey("When the clock is advanced by less than the TTL", func() {
+			srv.Advance(20 * time.Second)
+			val, err := rdb.Get(ctx, "key").Result()
+			ttl, ttlErr := rdb.PTTL(ctx, "key").Result()
+
+			Convey("Then the key still exists with its remaining TTL", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "value")
+				So(ttlErr, ShouldBeNil)
+				So(ttl, ShouldEqual, 40*time.Second)
+			})
+		})
+
+		Convey("When the clock is advanced past the TTL", func() {
+			srv.Advance(time.Minute)
+			_, err := rdb.Get(ctx, "key").Result()
+			ttl, ttlErr := rdb.TTL(ctx, "key").Result()
+
+			Convey("Then the key has expired", func() {
+				So(err, ShouldEqual, redis.Nil)
+				So(ttlErr, ShouldBeNil)
+				So(ttl, ShouldEqual, -2)
+			})
+		})
+
+		Convey("When the clock is set to a time after the key expires", func() {
+			srv.SetTime(srv.Now().Add(time.Hour))
+			n, err := rdb.Exists(ctx, "key").Result()
+
+			Convey("Then the key has expired", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the key is expired at a unix time", func() {
+			So(rdb.ExpireAt(ctx, "key", srv.Now().Add(10*time.Second)).Err(), ShouldBeNil)
+			srv.Advance(10 * time.Second)
+			n, err := rdb.Exists(ctx, "key").Result()
+
+			Convey("Then it expires at that time", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestServer_Transactions(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server with two clients", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		other := srv.NewClient()
+		defer other.Close()
+
+		So(rdb.Set(ctx, "counter", "1", 0).Err(), ShouldBeNil)
+
+		Convey("When a transaction reads and writes a watched key that is not modified", func() {
+			err := rdb.Watch(ctx, func(tx *redis.Tx) error {
+				val, err := tx.Get(ctx, "counter").Int()
+				if err != nil {
+					return err
+				}
+				_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+					pipe.Set(ctx, "counter", val+1, 0)
+					return nil
+				})
+				return err
+			}, "counter")
+
+			Convey("Then the transaction is applied", func() {
+				So(err, ShouldBeNil)
+				So(rdb.Get(ctx, "counter").Val(), ShouldEqual, "2")
+			})
+		})
+
+		Convey("When a watched key is modified by another client before the transaction is executed", func() {
+			err := rdb.Watch(ctx, func(tx *redis.Tx) error {
+				So(other.Set(ctx, "counter", "10", 0).Err(), ShouldBeNil)
+				_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+					pipe.Set(ctx, "counter", "2", 0)
+					return nil
+				})
+				return err
+			}, "counter")
+
+			Convey("Then the transaction fails and the other client's write is kept", func() {
+				So(err, ShouldEqual, redis.TxFailedErr)
+				So(rdb.Get(ctx, "counter").Val(), ShouldEqual, "10")
+			})
+		})
+
+		Convey("When a transaction holds a command that fails when it is executed", func() {
+			cmds, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.HGet(ctx, "counter", "field")
+				pipe.Incr(ctx, "counter")
+				return nil
+			})
+
+			Convey("Then the other commands are still applied", func() {
+				So(err, ShouldNotBeNil)
+				So(cmds[0].Err().Error(), ShouldStartWith, "WRONGTYPE")
+				So(cmds[1].Err(), ShouldBeNil)
+				So(rdb.Get(ctx, "counter").Val(), ShouldEqual, "2")
+			})
+		})
+
+		Convey("When a transaction holds an unknown command", func() {
+			_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Incr(ctx, "counter")
+				pipe.Do(ctx, "unknown")
+				return nil
+			})
+
+			Convey("Then the transaction is discarded", func() {
+				So(err, ShouldNotBeNil)
+				So(rdb.Get(ctx, "counter").Val(), ShouldEqual, "1")
+			})
+		})
+	})
+}
+
+func TestServer_Pipelines(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		Convey("When a large pipeline is sent", func() {
+			value := string(make([]byte, 1024))
+			cmds, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				for i := range 1000 {
+					pipe.Set(ctx, fmt.Sprintf("key:%d", i), value, 0)
+				}
+				return nil
+			})
+
+			Convey("Then every command is executed", func() {
+				So(err, ShouldBeNil)
+				So(cmds, ShouldHaveLength, 1000)
+				So(rdb.DBSize(ctx).Val(), ShouldEqual, 1000)
+			})
+		})
+	})
+}
+
+func TestServer_Close(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server with a connected client", t, func() {
+		srv := NewServer()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		So(rdb.Ping(ctx).Err(), ShouldBeNil)
+
+		Convey("When the server is closed", func() {
+			So(srv.Close(), ShouldBeNil)
+			err := rdb.Ping(ctx).Err()
+
+			Convey("Then the client can no longer reach it", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the clients are disconnected", func() {
+			So(rdb.Set(ctx, "key", "value", 0).Err(), ShouldBeNil)
+			srv.DisconnectClients()
+			pingErr := rdb.Ping(ctx).Err()
+			val, err := rdb.Get(ctx, "key").Result()
+
+			Convey("Then the next command fails, and the client then reconnects and the keys are kept", func() {
+				So(pingErr, ShouldNotBeNil)
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "value")
+			})
+		})
+	})
+}
+
+func TestServer_DisRedisClient(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a dis-redis client backed by a fake server", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		cli := disRedis.NewClientWithCustomClient(ctx, &disRedis.ClientConfig{KeyPrefix: "svc:"}, srv.NewClient())
+		defer cli.Close(ctx)
+
+		So(cli.SetValue(ctx, "a", "1", time.Minute), ShouldBeNil)
+		So(cli.SetValue(ctx, "b", "2", 0), ShouldBeNil)
+
+		Convey("When the values are read", func() {
+			val, err := cli.GetValue(ctx, "a")
+			pairs, cursor, pairsErr := cli.GetKeyValuePairs(ctx, "*", 100, 0)
+			total, totalErr := cli.GetTotalKeys(ctx)
+
+			Convey("Then they are returned", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "1")
+				So(pairsErr, ShouldBeNil)
+				So(cursor, ShouldEqual, 0)
+				So(pairs, ShouldResemble, map[string]string{"a": "1", "b": "2"})
+				So(totalErr, ShouldBeNil)
+				So(total, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When a value expires", func() {
+			srv.Advance(time.Minute)
+			_, err := cli.GetValue(ctx, "a")
+
+			Convey("Then it is not found", func() {
+				So(err, ShouldEqual, disRedis.ErrKeyNotFound)
+			})
+		})
+
+		Convey("When a value is updated in a transaction", func() {
+			err := cli.Transaction(ctx, []string{"b"}, func(tx *disRedis.Tx) error {
+				val, err := tx.GetValue(ctx, "b")
+				if err != nil {
+					return err
+				}
+				tx.SetValue("b", val+"0", 0)
+				return nil
+			})
+
+			Convey("Then the update is applied", func() {
+				So(err, ShouldBeNil)
+				val, err := cli.GetValue(ctx, "b")
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "20")
+			})
+		})
+
+		Convey("When a key holding another type is read", func() {
+			rdb := srv.NewClient()
+			defer rdb.Close()
+
+			So(rdb.HSet(ctx, "svc:h", "field", "value").Err(), ShouldBeNil)
+			_, err := cli.GetValue(ctx, "h")
+
+			Convey("Then the error is classified", func() {
+				So(errors.Is(err, disRedis.ErrWrongType), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,418 @@
+package fake
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// scoredMember is a member of a sorted set and its score
+type scoredMember struct {
+	member string
+	score  float64
+}
+
+// sorted returns the members of the sorted set ordered by score, then lexicographically
+func (z sortedSet) sorted() []scoredMember {
+	members := make([]scoredMember, 0, len(z))
+	for member, score := range z {
+		members = append(members, scoredMember{member: member, score: score})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].score != members[j].score {
+			return members[i].score < members[j].score
+		}
+		return members[i].member < members[j].member
+	})
+
+	return members
+}
+
+// errScoreNaN is returned when incrementing a score would make it NaN, such as adding -inf to +inf
+var errScoreNaN = replyError("ERR resulting score is not a number (NaN)")
+
+func cmdZAdd(s *Server, args []string) any {
+	var nx, xx, gt, lt, ch, incr bool
+
+	i := 1
+flags:
+	for ; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "GT":
+			gt = true
+		case "LT":
+			lt = true
+		case "CH":
+			ch = true
+		case "INCR":
+			incr = true
+		default:
+			break flags
+		}
+	}
+
+	pairs := args[i:]
+	switch {
+	case len(pairs) == 0 || len(pairs)%2 != 0:
+		return errSyntax
+	case nx && xx:
+		return replyError("ERR XX and NX options at the same time are not compatible")
+	case (gt && lt) || (nx && (gt || lt)):
+		return replyError("ERR GT, LT, and/or NX options at the same time are not compatible")
+	case incr && len(pairs) != 2:
+		return replyError("ERR INCR option supports a single increment-element pair")
+	}
+
+	scores := make([]float64, len(pairs)/2)
+	for j := range scores {
+		score, err := parseFloat(pairs[j*2])
+		if err != nil {
+			return err
+		}
+		scores[j] = score
+	}
+
+	z, exists, err := lookupValue[sortedSet](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		z = sortedSet{}
+	}
+
+	var added, changed int
+	var result any
+	for j, score := range scores {
+		member := pairs[j*2+1]
+		current, isMember := z[member]
+
+		if (nx && isMember) || (xx && !isMember) {
+			continue
+		}
+
+		if incr {
+			if score += current; math.IsNaN(score) {
+				return errScoreNaN
+			}
+		}
+
+		if isMember && ((gt && score <= current) || (lt && score >= current)) {
+			continue
+		}
+
+		switch {
+		case !isMember:
+			added++
+		case score != current:
+			changed++
+		}
+
+		z[member] = score
+		result = score
+	}
+
+	if !exists && len(z) > 0 {
+		s.store(args[0], z, time.Time{})
+	} else if added+changed > 0 {
+		s.touch(args[0])
+	}
+
+	switch {
+	case incr:
+		return result
+	case ch:
+		return added + changed
+	}
+
+	return added
+}
+
+func cmdZIncrBy(s *Server, args []string) any {
+	increment, err := parseFloat(args[1])
+	if err != nil {
+		return err
+	}
+
+	z, err := lookupOrCreate(s, args[0], func() sortedSet { return sortedSet{} })
+	if err != nil {
+		return err
+	}
+
+	score := z[args[2]] + increment
+	if math.IsNaN(score) {
+		s.removeIfEmpty(args[0], len(z))
+		return errScoreNaN
+	}
+
+	z[args[2]] = score
+	s.touch(args[0])
+
+	return score
+}
+
+func cmdZScore(s *Server, args []string) any {
+	z, _, err := lookupValue[sortedSet](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	if score, isMember := z[args[1]]; isMember {
+		return score
+	}
+
+	return nil
+}
+
+func cmdZCard(s *Server, args []string) any {
+	z, _, err := lookupValue[sortedSet](s, args[0])
+	if err != nil {
+		return err
+	}
+	return len(z)
+}
+
+func cmdZRem(s *Server, args []string) any {
+	z, exists, err := lookupValue[sortedSet](s, args[0])
+	if err != nil || !exists {
+		return replyOrZero(err)
+	}
+
+	var removed int
+	for _, member := range args[1:] {
+		if _, isMember := z[member]; isMember {
+			delete(z, member)
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		s.removeIfEmpty(args[0], len(z))
+	}
+
+	return removed
+}
+
+// rankCommand returns the ZREVRANK command if rev is true, or the ZRANK command
+func rankCommand(rev bool) func(s *Server, args []string) any {
+	return func(s *Server, args []string) any {
+		z, _, err := lookupValue[sortedSet](s, args[0])
+		if err != nil {
+			return err
+		}
+
+		if _, isMember := z[args[1]]; !isMember {
+			return nil
+		}
+
+		members := z.sorted()
+		if rev {
+			slices.Reverse(members)
+		}
+
+		return slices.IndexFunc(members, func(m scoredMember) bool { return m.member == args[1] })
+	}
+}
+
+// zrangeAlias returns a legacy ZRANGE command, such as ZRANGEBYSCORE, which runs ZRANGE with flags
+func zrangeAlias(flags ...string) func(s *Server, args []string) any {
+	return func(s *Server, args []string) any {
+		return cmdZRange(s, slices.Concat(args[:3], flags, args[3:]))
+	}
+}
+
+func cmdZRange(s *Server, args []string) any {
+	var byScore, byLex, rev, withScores, limit bool
+	offset, count := int64(0), int64(-1)
+
+	for i := 3; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "BYSCORE":
+			byScore = true
+		case "BYLEX":
+			byLex = true
+		case "REV":
+			rev = true
+		case "WITHSCORES":
+			withScores = true
+		case "LIMIT":
+			if i+2 >= len(args) {
+				return errSyntax
+			}
+
+			var err error
+			if offset, err = parseInt(args[i+1]); err != nil {
+				return err
+			}
+			if count, err = parseInt(args[i+2]); err != nil {
+				return err
+			}
+
+			limit = true
+			i += 2
+		default:
+			return errSyntax
+		}
+	}
+
+	switch {
+	case byScore && byLex:
+		return errSyntax
+	case limit && !byScore && !byLex:
+		return replyError("ERR syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX")
+	case withScores && byLex:
+		return replyError("ERR syntax error, WITHSCORES not supported in combination with BYLEX")
+	}
+
+	z, _, err := lookupValue[sortedSet](s, args[0])
+	if err != nil {
+		return err
+	}
+
+	members := z.sorted()
+	if rev {
+		slices.Reverse(members)
+	}
+
+	// With REV, ranges by score or lex are given from the maximum to the minimum
+	lower, upper := args[1], args[2]
+	if rev {
+		lower, upper = upper, lower
+	}
+
+	switch {
+	case byScore:
+		lo, hi, err := parseScoreRange(lower, upper)
+		if err != nil {
+			return err
+		}
+		members = slices.DeleteFunc(members, func(m scoredMember) bool {
+			return !lo.below(m.score) || !hi.above(m.score)
+		})
+	case byLex:
+		lo, hi, err := parseLexRange(lower, upper)
+		if err != nil {
+			return err
+		}
+		members = slices.DeleteFunc(members, func(m scoredMember) bool {
+			return !lo.below(m.member) || !hi.above(m.member)
+		})
+	default:
+		start, stop, err := parseRange(args[1], args[2])
+		if err != nil {
+			return err
+		}
+
+		first, last, ok := normaliseRange(start, stop, len(members))
+		if !ok {
+			return []string{}
+		}
+		members = members[first : last+1]
+	}
+
+	if limit {
+		if offset < 0 || offset >= int64(len(members)) {
+			return []string{}
+		}
+		members = members[offset:]
+		if count >= 0 && count < int64(len(members)) {
+			members = members[:count]
+		}
+	}
+
+	reply := make([]string, 0, len(members))
+	for _, m := range members {
+		reply = append(reply, m.member)
+		if withScores {
+			reply = append(reply, formatFloat(m.score))
+		}
+	}
+
+	return reply
+}
+
+// bound is an inclusive or exclusive end of a range of scores or members
+type bound[T float64 | string] struct {
+	value     T
+	exclusive bool
+	// infinite is -1 or 1 for the - and + ends of a lex range, which are below and above every member
+	infinite int
+}
+
+// below reports whether the bound is below v, so v is within a range starting at the bound
+func (b bound[T]) below(v T) bool {
+	switch {
+	case b.infinite != 0:
+		return b.infinite < 0
+	case b.exclusive:
+		return b.value < v
+	}
+	return b.value <= v
+}
+
+// above reports whether the bound is above v, so v is within a range ending at the bound
+func (b bound[T]) above(v T) bool {
+	switch {
+	case b.infinite != 0:
+		return b.infinite > 0
+	case b.exclusive:
+		return b.value > v
+	}
+	return b.value >= v
+}
+
+// parseScoreRange parses the minimum and maximum of a range of scores, such as 1 and (5 or -inf and +inf
+func parseScoreRange(lower, upper string) (bound[float64], bound[float64], error) {
+	parse := func(arg string) (bound[float64], error) {
+		var b bound[float64]
+		b.exclusive = strings.HasPrefix(arg, "(")
+
+		value, err := parseFloat(strings.TrimPrefix(arg, "("))
+		if err != nil {
+			return b, errMinMaxNotFloat
+		}
+		b.value = value
+
+		return b, nil
+	}
+
+	lo, err := parse(lower)
+	if err != nil {
+		return lo, lo, err
+	}
+
+	hi, err := parse(upper)
+	return lo, hi, err
+}
+
+// parseLexRange parses the minimum and maximum of a range of members, such as [a and (c or - and +
+func parseLexRange(lower, upper string) (bound[string], bound[string], error) {
+	parse := func(arg string) (bound[string], error) {
+		switch {
+		case arg == "-":
+			return bound[string]{infinite: -1}, nil
+		case arg == "+":
+			return bound[string]{infinite: 1}, nil
+		case strings.HasPrefix(arg, "["):
+			return bound[string]{value: arg[1:]}, nil
+		case strings.HasPrefix(arg, "("):
+			return bound[string]{value: arg[1:], exclusive: true}, nil
+		}
+		return bound[string]{}, errMinMaxNotLex
+	}
+
+	lo, err := parse(lower)
+	if err != nil {
+		return lo, lo, err
+	}
+
+	hi, err := parse(upper)
+	return lo, hi, err
+}
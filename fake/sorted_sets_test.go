@@ -0,0 +1,95 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestServer_SortedSets(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a fake server holding a sorted set", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		rdb := srv.NewClient()
+		defer rdb.Close()
+
+		So(rdb.ZAdd(ctx, "scores",
+			redis.Z{Score: 3, Member: "c"},
+			redis.Z{Score: 1, Member: "a"},
+			redis.Z{Score: 2, Member: "b"},
+			redis.Z{Score: 2, Member: "bb"},
+		).Err(), ShouldBeNil)
+
+		Convey("When a range of ranks is read", func() {
+			members, err := rdb.ZRange(ctx, "scores", 0, 1).Result()
+			rev, revErr := rdb.ZRevRangeWithScores(ctx, "scores", 0, 0).Result()
+
+			Convey("Then the members are ordered by score, then member", func() {
+				So(err, ShouldBeNil)
+				So(members, ShouldResemble, []string{"a", "b"})
+				So(revErr, ShouldBeNil)
+				So(rev, ShouldResemble, []redis.Z{{Score: 3, Member: "c"}})
+			})
+		})
+
+		Convey("When a range of scores is read", func() {
+			members, err := rdb.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "scores", Start: "(1", Stop: "+inf", ByScore: true, Offset: 1, Count: 2}).Result()
+			rev, revErr := rdb.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "scores", Start: 1, Stop: 2, ByScore: true, Rev: true}).Result()
+
+			Convey("Then the members within the range are returned", func() {
+				So(err, ShouldBeNil)
+				So(members, ShouldResemble, []string{"bb", "c"})
+				So(revErr, ShouldBeNil)
+				So(rev, ShouldResemble, []string{"bb", "b", "a"})
+			})
+		})
+
+		Convey("When a range of members is read", func() {
+			members, err := rdb.ZRangeArgs(ctx, redis.ZRangeArgs{Key: "scores", Start: "[b", Stop: "(c", ByLex: true}).Result()
+
+			Convey("Then the members within the range are returned", func() {
+				So(err, ShouldBeNil)
+				So(members, ShouldResemble, []string{"b", "bb"})
+			})
+		})
+
+		Convey("When scores are updated only if they increase", func() {
+			changed, err := rdb.ZAddArgs(ctx, "scores", redis.ZAddArgs{GT: true, Ch: true, Members: []redis.Z{
+				{Score: 10, Member: "a"},
+				{Score: 0, Member: "c"},
+			}}).Result()
+
+			Convey("Then only the increased score is changed", func() {
+				So(err, ShouldBeNil)
+				So(changed, ShouldEqual, 1)
+				So(rdb.ZScore(ctx, "scores", "a").Val(), ShouldEqual, 10)
+				So(rdb.ZScore(ctx, "scores", "c").Val(), ShouldEqual, 3)
+			})
+		})
+
+		Convey("When a score is incremented", func() {
+			score, err := rdb.ZIncrBy(ctx, "scores", 5, "a").Result()
+
+			Convey("Then the member's rank changes", func() {
+				So(err, ShouldBeNil)
+				So(score, ShouldEqual, 6)
+				So(rdb.ZRevRank(ctx, "scores", "a").Val(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When members are removed", func() {
+			n, err := rdb.ZRem(ctx, "scores", "a", "missing").Result()
+
+			Convey("Then the number removed is returned", func() {
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 1)
+				So(rdb.ZCard(ctx, "scores").Val(), ShouldEqual, 3)
+			})
+		})
+	})
+}
@@ -0,0 +1,304 @@
+package fake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// invalidationChannel is the channel that invalidations are published to for clients that redirect them
+const invalidationChannel = "__redis__:invalidate"
+
+// trackedReads are the commands whose keys are remembered for clients with tracking enabled, so that they are sent
+// an invalidation when the keys change. The value is whether every argument is a key rather than only the first.
+var trackedReads = map[string]bool{
+	"get":       false,
+	"getex":     false,
+	"mget":      true,
+	"exists":    true,
+	"strlen":    false,
+	"hget":      false,
+	"hmget":     false,
+	"hgetall":   false,
+	"smembers":  false,
+	"sismember": false,
+	"lrange":    false,
+	"zrange":    false,
+	"zscore":    false,
+}
+
+// tracking is the client side caching state of a connection, set by CLIENT TRACKING
+type tracking struct {
+	redirect int64
+	bcast    bool
+	prefixes []string
+}
+
+// clientCommand runs the commands for client side caching and subscriptions, and restricts the commands of a
+// subscribed connection, reporting whether the command was handled
+func (c *conn) clientCommand(name string, args []string) (any, bool) {
+	switch name {
+	case "client":
+		return c.client(args[1:]), true
+	case "subscribe":
+		return c.subscribe(args[1:]), true
+	case "unsubscribe":
+		return c.unsubscribe(args[1:]), true
+	}
+
+	if c.isSubscribed() {
+		return c.subscribedCommand(name, args), true
+	}
+
+	return nil, false
+}
+
+// client runs a CLIENT subcommand for the connection
+func (c *conn) client(args []string) any {
+	if len(args) == 0 {
+		return wrongArity("client")
+	}
+
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+
+	switch strings.ToLower(args[0]) {
+	case "id":
+		return c.id
+	case "getredir":
+		if c.tracking == nil {
+			return int64(-1)
+		}
+		return c.tracking.redirect
+	case "tracking":
+		return c.setTracking(args[1:])
+	}
+
+	return replyError(fmt.Sprintf("ERR unknown subcommand '%s'. Try CLIENT HELP.", args[0]))
+}
+
+// setTracking enables or disables tracking with the arguments of CLIENT TRACKING. The caller must hold server.mu.
+func (c *conn) setTracking(args []string) any {
+	if len(args) == 0 {
+		return wrongArity("client|tracking")
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "off":
+		c.tracking = nil
+		return replyOK
+	case "on":
+	default:
+		return errSyntax
+	}
+
+	t := &tracking{}
+	for i := 1; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "bcast":
+			t.bcast = true
+		case "redirect", "prefix":
+			if i+1 == len(args) {
+				return errSyntax
+			}
+			i++
+			if strings.EqualFold(args[i-1], "prefix") {
+				t.prefixes = append(t.prefixes, args[i])
+				continue
+			}
+			id, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return errNotInteger
+			}
+			t.redirect = id
+		default:
+			return errSyntax
+		}
+	}
+
+	if len(t.prefixes) > 0 && !t.bcast {
+		return replyError("ERR PREFIX option requires BCAST mode to be enabled")
+	}
+
+	if c.tracking != nil && c.tracking.bcast != t.bcast {
+		return replyError("ERR You can't switch BCAST mode on/off before disabling tracking for this client, " +
+			"and then re-enabling it with a different mode.")
+	}
+
+	if t.redirect != 0 {
+		if _, exists := c.server.clients[t.redirect]; !exists {
+			return replyError("ERR The client ID you want redirect to does not exist")
+		}
+	}
+
+	c.tracking = t
+
+	return replyOK
+}
+
+// subscribe subscribes the connection to channels, returning the confirmation of the last channel after queueing
+// the others
+func (c *conn) subscribe(channels []string) any {
+	if len(channels) == 0 {
+		return wrongArity("subscribe")
+	}
+
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+
+	if c.subscribed == nil {
+		c.subscribed = make(map[string]struct{})
+	}
+
+	var confirmation []any
+	for i, channel := range channels {
+		c.subscribed[channel] = struct{}{}
+		confirmation = []any{"subscribe", channel, len(c.subscribed)}
+		if i < len(channels)-1 {
+			c.reply(confirmation)
+		}
+	}
+
+	return confirmation
+}
+
+// unsubscribe unsubscribes the connection from channels, or from every channel if none are given
+func (c *conn) unsubscribe(channels []string) any {
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+
+	if len(channels) == 0 {
+		for channel := range c.subscribed {
+			channels = append(channels, channel)
+		}
+		if len(channels) == 0 {
+			return []any{"unsubscribe", nil, 0}
+		}
+	}
+
+	var confirmation []any
+	for i, channel := range channels {
+		delete(c.subscribed, channel)
+		confirmation = []any{"unsubscribe", channel, len(c.subscribed)}
+		if i < len(channels)-1 {
+			c.reply(confirmation)
+		}
+	}
+
+	return confirmation
+}
+
+// subscribedCommand runs a command sent while the connection is subscribed, when only the commands for managing
+// subscriptions and PING are allowed
+func (c *conn) subscribedCommand(name string, args []string) any {
+	switch name {
+	case "ping":
+		payload := ""
+		if len(args) > 1 {
+			payload = args[1]
+		}
+		return []any{"pong", payload}
+	case "quit", "reset":
+		return replyOK
+	}
+
+	return replyError(fmt.Sprintf("ERR Can't execute '%s': only (P|S)SUBSCRIBE / (P|S)UNSUBSCRIBE / PING / QUIT / RESET "+
+		"are allowed in this context", name))
+}
+
+// isSubscribed reports whether the connection is subscribed to any channel
+func (c *conn) isSubscribed() bool {
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+
+	return len(c.subscribed) > 0
+}
+
+// trackRead remembers the keys read by a command for a connection with tracking enabled. The caller must hold
+// server.mu.
+func (c *conn) trackRead(name string, args []string) {
+	if c.tracking == nil || c.tracking.bcast {
+		return
+	}
+
+	allKeys, tracked := trackedReads[name]
+	if !tracked || len(args) == 0 {
+		return
+	}
+
+	keys := args[:1]
+	if allKeys {
+		keys = args
+	}
+
+	for _, key := range keys {
+		if c.server.tracked[key] == nil {
+			c.server.tracked[key] = make(map[int64]struct{})
+		}
+		c.server.tracked[key][c.id] = struct{}{}
+	}
+}
+
+// invalidate sends an invalidation for key to the connections tracking it. The caller must hold s.mu.
+func (s *Server) invalidate(key string) {
+	for id := range s.tracked[key] {
+		if c, exists := s.clients[id]; exists && c.tracking != nil && !c.tracking.bcast {
+			s.sendInvalidation(c, []string{key})
+		}
+	}
+	delete(s.tracked, key)
+
+	for c := range s.conns {
+		if c.tracking != nil && c.tracking.bcast && hasAnyPrefix(key, c.tracking.prefixes) {
+			s.sendInvalidation(c, []string{key})
+		}
+	}
+}
+
+// invalidateAll sends an invalidation without keys, as redis does when it flushes its keyspace, to every connection
+// with tracking enabled. The caller must hold s.mu.
+func (s *Server) invalidateAll() {
+	s.tracked = make(map[string]map[int64]struct{})
+
+	for c := range s.conns {
+		if c.tracking != nil {
+			s.sendInvalidation(c, nil)
+		}
+	}
+}
+
+// sendInvalidation publishes keys to the connection that c redirects its invalidations to, if it is subscribed to
+// the invalidation channel. Invalidations are not sent to the connection itself, as that requires RESP3.
+// The caller must hold s.mu.
+func (s *Server) sendInvalidation(c *conn, keys []string) {
+	target, exists := s.clients[c.tracking.redirect]
+	if !exists {
+		return
+	}
+
+	if _, subscribed := target.subscribed[invalidationChannel]; !subscribed {
+		return
+	}
+
+	var payload any = nilArray{}
+	if keys != nil {
+		payload = keys
+	}
+
+	target.reply([]any{"message", invalidationChannel, payload})
+}
+
+// hasAnyPrefix reports whether key starts with any of prefixes, which is true of every key if there are none
+func hasAnyPrefix(key string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newConfiguredClient returns a client of srv whose options have been changed by configure
+func newConfiguredClient(srv *Server, configure func(options *redis.Options)) *redis.Client {
+	client := srv.NewClient()
+	options := *client.Options()
+	client.Close()
+
+	configure(&options)
+
+	return redis.NewClient(&options)
+}
+
+func TestServer_Tracking(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a client subscribed to invalidations and a client that redirects its invalidations to it", t, func() {
+		srv := NewServer()
+		defer srv.Close()
+
+		var subscriberID int64
+		subscriber := newConfiguredClient(srv, func(options *redis.Options) {
+			options.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
+				var err error
+				subscriberID, err = cn.ClientID(ctx).Result()
+				return err
+			}
+		})
+		defer subscriber.Close()
+
+		pubsub := subscriber.Subscribe(ctx, invalidationChannel)
+		defer pubsub.Close()
+		_, err := pubsub.ReceiveTimeout(ctx, time.Second)
+		So(err, ShouldBeNil)
+
+		reader := newConfiguredClient(srv, func(options *redis.Options) {
+			options.PoolSize = 1
+		})
+		defer reader.Close()
+
+		writer := srv.NewClient()
+		defer writer.Close()
+
+		So(writer.Set(ctx, "user:1", "a", 0).Err(), ShouldBeNil)
+		So(writer.Set(ctx, "other", "b", 0).Err(), ShouldBeNil)
+
+		Convey("When the client tracks the keys it reads and another client modifies one", func() {
+			So(reader.Do(ctx, "client", "tracking", "on", "redirect", subscriberID).Err(), ShouldBeNil)
+			So(reader.Get(ctx, "user:1").Err(), ShouldBeNil)
+			So(writer.Set(ctx, "other", "c", 0).Err(), ShouldBeNil)
+			So(writer.Set(ctx, "user:1", "d", 0).Err(), ShouldBeNil)
+
+			msg, err := pubsub.ReceiveTimeout(ctx, time.Second)
+
+			Convey("Then only the key it read is invalidated", func() {
+				So(err, ShouldBeNil)
+				So(msg.(*redis.Message).Channel, ShouldEqual, invalidationChannel)
+				So(msg.(*redis.Message).PayloadSlice, ShouldResemble, []string{"user:1"})
+			})
+		})
+
+		Convey("When the client tracks keys by prefix and other keys are modified", func() {
+			So(reader.Do(ctx, "client", "tracking", "on", "redirect", subscriberID, "bcast", "prefix", "user:").Err(), ShouldBeNil)
+			So(writer.Set(ctx, "other", "c", 0).Err(), ShouldBeNil)
+			So(writer.Del(ctx, "user:1").Err(), ShouldBeNil)
+
+			msg, err := pubsub.ReceiveTimeout(ctx, time.Second)
+
+			Convey("Then only keys with the prefix are invalidated, whether or not they were read", func() {
+				So(err, ShouldBeNil)
+				So(msg.(*redis.Message).PayloadSlice, ShouldResemble, []string{"user:1"})
+			})
+		})
+
+		Convey("When the client tracks keys and the server is flushed", func() {
+			So(reader.Do(ctx, "client", "tracking", "on", "redirect", subscriberID).Err(), ShouldBeNil)
+			srv.FlushAll()
+
+			_, err := pubsub.ReceiveTimeout(ctx, time.Second)
+
+			Convey("Then an invalidation without keys is sent, which go-redis cannot parse as a message", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "unsupported pubsub message payload")
+			})
+		})
+
+		Convey("When the client redirects its invalidations to a client that does not exist", func() {
+			err := reader.Do(ctx, "client", "tracking", "on", "redirect", subscriberID+100).Err()
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "does not exist")
+			})
+		})
+
+		Convey("When the client tracks prefixes without broadcasting", func() {
+			err := reader.Do(ctx, "client", "tracking", "on", "prefix", "user:").Err()
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "requires BCAST")
+			})
+		})
+
+		Convey("When the subscribed client is pinged", func() {
+			err := pubsub.Ping(ctx, "hello")
+			msg, receiveErr := pubsub.ReceiveTimeout(ctx, time.Second)
+
+			Convey("Then it replies with a pong message", func() {
+				So(err, ShouldBeNil)
+				So(receiveErr, ShouldBeNil)
+				So(msg.(*redis.Pong).Payload, ShouldEqual, "hello")
+			})
+		})
+	})
+}
@@ -4,8 +4,10 @@ go 1.24
 
 require (
 	github.com/ONSdigital/dp-healthcheck v1.6.4
+	github.com/ONSdigital/log.go/v2 v2.4.5
 	github.com/aws/aws-sdk-go-v2 v1.41.1
 	github.com/aws/aws-sdk-go-v2/config v1.32.7
+	github.com/klauspost/compress v1.18.0
 	github.com/redis/go-redis/v9 v9.17.2
 	github.com/smartystreets/goconvey v1.8.1
 )
@@ -13,7 +15,6 @@ require (
 require (
 	github.com/ONSdigital/dp-api-clients-go/v2 v2.267.0 // indirect
 	github.com/ONSdigital/dp-net/v3 v3.3.0 // indirect
-	github.com/ONSdigital/log.go/v2 v2.4.5 // indirect
 	github.com/aws/aws-sdk-go-v2/credentials v1.19.7 // indirect
 	github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.18.17 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/configsources v1.4.17 // indirect
@@ -2,19 +2,36 @@ package redis
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 )
 
-const MsgHealthy = "redis is healthy"
+const (
+	MsgHealthy                = "redis is healthy"
+	MsgSubscriptionsUnhealthy = "redis is healthy but %d subscription(s) are disconnected: %v"
+	MsgCircuitOpen            = "redis circuit breaker is open"
+	MsgCircuitHalfOpen        = "redis is recovering, circuit breaker is half-open"
+)
 
-// Checker executes all healthchecks and then updates the health state
+// Checker executes all healthchecks and then updates the health state. The state is a warning if redis can be
+// reached but any subscriptions are disconnected or the circuit breaker is half-open, and critical without contacting
+// redis while the circuit breaker is open. Once the breaker's OpenTimeout has passed the check's ping is sent as the
+// trial call, so the circuit can close, and the check recover, without any other traffic.
 func (cli *Client) Checker(ctx context.Context, state *health.CheckState) error {
 	if state == nil {
 		state = &health.CheckState{}
 	}
 
+	if cli.CircuitState() == CircuitOpen {
+		if updateErr := state.Update(health.StatusCritical, MsgCircuitOpen, http.StatusServiceUnavailable); updateErr != nil {
+			return updateErr
+		}
+
+		return nil
+	}
+
 	statusCode, err := cli.Ping(ctx)
 	if err != nil {
 		if updateErr := state.Update(health.StatusCritical, err.Error(), statusCode); updateErr != nil {
@@ -24,6 +41,28 @@ func (cli *Client) Checker(ctx context.Context, state *health.CheckState) error
 		return nil
 	}
 
+	if unhealthy := cli.unhealthySubscriptions(); len(unhealthy) > 0 {
+		channels := make([]string, 0, len(unhealthy))
+		for _, s := range unhealthy {
+			channels = append(channels, s.channels...)
+		}
+
+		msg := fmt.Sprintf(MsgSubscriptionsUnhealthy, len(unhealthy), channels)
+		if updateErr := state.Update(health.StatusWarning, msg, statusCode); updateErr != nil {
+			return updateErr
+		}
+
+		return nil
+	}
+
+	if cli.CircuitState() == CircuitHalfOpen {
+		if updateErr := state.Update(health.StatusWarning, MsgCircuitHalfOpen, statusCode); updateErr != nil {
+			return updateErr
+		}
+
+		return nil
+	}
+
 	if updateErr := state.Update(health.StatusOK, MsgHealthy, statusCode); updateErr != nil {
 		return updateErr
 	}
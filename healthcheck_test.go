@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -63,4 +64,30 @@ func TestChecker(t *testing.T) {
 			So(checkState.StatusCode(), ShouldEqual, http.StatusInternalServerError)
 		})
 	})
+
+	Convey("Given that Redis is healthy but a subscription is disconnected", t, func() {
+		ctx := context.Background()
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			PingFunc: func(ctx context.Context) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx)
+				cmd.SetVal("pong")
+				return cmd
+			},
+		}
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, mockRedisClient)
+		client.subscriptions = map[*subscription]struct{}{
+			{channels: []string{"invalidate"}, healthy: false}: {},
+		}
+		checkState := health.NewCheckState("dis-redis-test")
+
+		Convey("Checker updates the CheckState to a warning state", func() {
+			client.Checker(context.Background(), checkState)
+
+			So(checkState.Status(), ShouldEqual, health.StatusWarning)
+			So(checkState.Message(), ShouldEqual, fmt.Sprintf(MsgSubscriptionsUnhealthy, 1, []string{"invalidate"}))
+			So(checkState.StatusCode(), ShouldEqual, http.StatusOK)
+		})
+	})
 }
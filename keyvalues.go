@@ -0,0 +1,171 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis key types, as returned by TYPE
+const (
+	TypeNone      = "none"
+	TypeString    = "string"
+	TypeHash      = "hash"
+	TypeList      = "list"
+	TypeSet       = "set"
+	TypeSortedSet = "zset"
+)
+
+// KeyValuePairsOptions controls how GetKeyValuePairsWithOptions handles keys that do not hold a string value.
+type KeyValuePairsOptions struct {
+	// IncludeMissing includes keys that were removed or expired between being scanned and their value being fetched,
+	// with ErrKeyNotFound as their error.
+	IncludeMissing bool
+
+	// ReportTypeMismatches includes keys that do not hold a string, with ErrWrongType as their error. It has no effect
+	// on hashes, lists, sets and sorted sets when FetchNativeTypes is set.
+	ReportTypeMismatches bool
+
+	// FetchNativeTypes fetches hashes, lists, sets and sorted sets with HGETALL, LRANGE, SMEMBERS and ZRANGE.
+	FetchNativeTypes bool
+}
+
+// KeyValue is the value of a single key returned by GetKeyValuePairsWithOptions. Type is the redis type of the key and
+// Value holds a string, a map[string]string for hashes, a []string for lists and sets, or a []ScoredMember for sorted
+// sets. Value is nil if Err is set.
+type KeyValue struct {
+	Type  string
+	Value interface{}
+	Err   error
+}
+
+// GetKeyValuePairsWithOptions retrieves a page of keys matching a pattern and their values in the same way as
+// GetKeyValuePairs, with opts controlling whether keys that no longer exist or do not hold a string are included.
+// Keys are only left out of the result if they do not hold a string and the options do not ask for them.
+func (cli *Client) GetKeyValuePairsWithOptions(ctx context.Context, matchPattern string, count int64, cursor uint64, opts KeyValuePairsOptions) (map[string]KeyValue, uint64, error) {
+	node, keys, newCursor, err := cli.scanPage(ctx, matchPattern, count, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	values, err := cli.getValues(ctx, node, keys)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make(map[string]KeyValue, len(keys))
+
+	var others []string
+	for _, key := range keys {
+		if val, ok := values[cli.stripKey(key)]; ok {
+			results[cli.stripKey(key)] = KeyValue{Type: TypeString, Value: val}
+		} else {
+			others = append(others, key)
+		}
+	}
+
+	if len(others) == 0 || opts == (KeyValuePairsOptions{}) {
+		return results, newCursor, nil
+	}
+
+	if err := cli.getOtherValues(ctx, node, others, opts, results); err != nil {
+		return nil, 0, err
+	}
+
+	return results, newCursor, nil
+}
+
+// getOtherValues looks up the type of keys that did not return a string from MGET and adds them to results as
+// requested by opts
+func (cli *Client) getOtherValues(ctx context.Context, node redis.UniversalClient, keys []string, opts KeyValuePairsOptions, results map[string]KeyValue) error {
+	typeCmds := make([]*redis.StatusCmd, len(keys))
+	_, err := node.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			typeCmds[i] = pipe.Type(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching types for keys: %w", err)
+	}
+
+	fetches := make(map[string]redis.Cmder)
+	_, _ = node.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			keyType := typeCmds[i].Val()
+			switch {
+			case keyType == TypeNone:
+				if opts.IncludeMissing {
+					results[cli.stripKey(key)] = KeyValue{Type: keyType, Err: ErrKeyNotFound}
+				}
+			case keyType == TypeString:
+				// the key was set after MGET, so fetch its new value
+				fetches[key] = pipe.Get(ctx, key)
+			case opts.FetchNativeTypes && keyType == TypeHash:
+				fetches[key] = pipe.HGetAll(ctx, key)
+			case opts.FetchNativeTypes && keyType == TypeList:
+				fetches[key] = pipe.LRange(ctx, key, 0, -1)
+			case opts.FetchNativeTypes && keyType == TypeSet:
+				fetches[key] = pipe.SMembers(ctx, key)
+			case opts.FetchNativeTypes && keyType == TypeSortedSet:
+				fetches[key] = pipe.ZRangeWithScores(ctx, key, 0, -1)
+			case opts.ReportTypeMismatches:
+				results[cli.stripKey(key)] = KeyValue{Type: keyType, Err: fmt.Errorf("%w: key %s holds a %s", ErrWrongType, cli.stripKey(key), keyType)}
+			}
+		}
+		return nil
+	})
+
+	// errors are reported for each key, as a failed pipeline sets the error on every command
+	for key, cmd := range fetches {
+		kv := cli.keyValue(ctx, key, cmd)
+		if errors.Is(kv.Err, ErrKeyNotFound) && !opts.IncludeMissing {
+			continue
+		}
+		results[cli.stripKey(key)] = kv
+	}
+
+	return nil
+}
+
+// keyValue converts the command used to fetch key into its KeyValue
+func (cli *Client) keyValue(ctx context.Context, key string, cmd redis.Cmder) KeyValue {
+	var kv KeyValue
+
+	switch cmd := cmd.(type) {
+	case *redis.StringCmd:
+		val, err := cli.decodeValue(ctx, key, cmd.Val())
+		if err != nil {
+			return KeyValue{Type: TypeString, Err: fmt.Errorf("error decoding value for key %s: %w", cli.stripKey(key), err)}
+		}
+		kv = KeyValue{Type: TypeString, Value: val}
+	case *redis.MapStringStringCmd:
+		kv = KeyValue{Type: TypeHash, Value: cmd.Val()}
+	case *redis.StringSliceCmd:
+		kv = KeyValue{Type: TypeList, Value: cmd.Val()}
+		if cmd.Name() == "smembers" {
+			kv.Type = TypeSet
+		}
+	case *redis.ZSliceCmd:
+		kv = KeyValue{Type: TypeSortedSet, Value: toScoredMembers(cmd.Val())}
+	}
+
+	err := cmd.Err()
+	switch {
+	case err == nil:
+		return kv
+	case errors.Is(err, redis.Nil):
+		// the key was removed between TYPE and fetching its value
+		kv.Err = ErrKeyNotFound
+	case redis.HasErrorPrefix(err, "WRONGTYPE"):
+		// the key was replaced by a different type between TYPE and fetching its value
+		kv.Err = fmt.Errorf("%w: key %s changed type while being fetched", ErrWrongType, cli.stripKey(key))
+	default:
+		kv.Err = fmt.Errorf("error fetching value for key %s: %w", cli.stripKey(key), err)
+	}
+	kv.Value = nil
+
+	return kv
+}
@@ -0,0 +1,93 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_GetKeyValuePairsWithOptions(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a page of keys holding different types of value", t, func() {
+		keyTypes := map[string]string{
+			"str":  TypeString,
+			"gone": TypeNone,
+			"hash": TypeHash,
+			"list": TypeList,
+			"set":  TypeSet,
+			"zset": TypeSortedSet,
+		}
+
+		redisClient, _ := newStubbedClient(func(cmd redis.Cmder) {
+			switch cmd := cmd.(type) {
+			case *redis.ScanCmd:
+				cmd.SetVal([]string{"str", "gone", "hash", "list", "set", "zset"}, 0)
+			case *redis.SliceCmd:
+				vals := make([]interface{}, len(cmd.Args())-1)
+				for i, key := range cmd.Args()[1:] {
+					if key == "str" {
+						vals[i] = "val_for_" + key.(string)
+					}
+				}
+				cmd.SetVal(vals)
+			case *redis.StatusCmd:
+				cmd.SetVal(keyTypes[fmt.Sprint(cmd.Args()[1])])
+			case *redis.MapStringStringCmd:
+				cmd.SetVal(map[string]string{"field": "value"})
+			case *redis.StringSliceCmd:
+				cmd.SetVal([]string{"a", "b"})
+			case *redis.ZSliceCmd:
+				cmd.SetVal([]redis.Z{{Member: "a", Score: 1}})
+			}
+		})
+
+		client := &Client{redisClient: redisClient}
+
+		Convey("When no options are set", func() {
+			results, _, err := client.GetKeyValuePairsWithOptions(ctx, "*", 10, 0, KeyValuePairsOptions{})
+
+			Convey("Then only string values are returned, as with GetKeyValuePairs", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldResemble, map[string]KeyValue{
+					"str": {Type: TypeString, Value: "val_for_str"},
+				})
+			})
+		})
+
+		Convey("When missing keys and type mismatches are requested", func() {
+			results, _, err := client.GetKeyValuePairsWithOptions(ctx, "*", 10, 0, KeyValuePairsOptions{
+				IncludeMissing:       true,
+				ReportTypeMismatches: true,
+			})
+
+			Convey("Then every key is returned with its own error", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldHaveLength, 6)
+				So(results["str"], ShouldResemble, KeyValue{Type: TypeString, Value: "val_for_str"})
+				So(results["gone"].Err, ShouldEqual, ErrKeyNotFound)
+				So(results["hash"].Type, ShouldEqual, TypeHash)
+				So(results["hash"].Err, ShouldWrap, ErrWrongType)
+				So(results["zset"].Err, ShouldWrap, ErrWrongType)
+			})
+		})
+
+		Convey("When native types are fetched", func() {
+			results, _, err := client.GetKeyValuePairsWithOptions(ctx, "*", 10, 0, KeyValuePairsOptions{FetchNativeTypes: true})
+
+			Convey("Then each type is fetched with its own command", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldResemble, map[string]KeyValue{
+					"str":  {Type: TypeString, Value: "val_for_str"},
+					"hash": {Type: TypeHash, Value: map[string]string{"field": "value"}},
+					"list": {Type: TypeList, Value: []string{"a", "b"}},
+					"set":  {Type: TypeSet, Value: []string{"a", "b"}},
+					"zset": {Type: TypeSortedSet, Value: []ScoredMember{{Member: "a", Score: 1}}},
+				})
+			})
+		})
+	})
+}
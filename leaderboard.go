@@ -0,0 +1,159 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LeaderboardConfig configures how a Leaderboard buckets and ranks scores.
+type LeaderboardConfig struct {
+	// Window is the period of time over which scores are ranked.
+	Window time.Duration
+	// BucketSize is the granularity scores are recorded at. Window should be a multiple of BucketSize.
+	BucketSize time.Duration
+	// Decay is the weight applied per bucket of age, between 0 and 1. A score recorded n buckets ago
+	// contributes score*Decay^n to the ranking. A value of 0 or 1 disables decay.
+	Decay float64
+}
+
+// Leaderboard ranks members by score within a sliding time window, e.g. to find the most popular content.
+type Leaderboard interface {
+	// Increment adds by to the score of member in the current time bucket.
+	Increment(ctx context.Context, member string, by float64) error
+	// Top returns up to count members with the highest decayed scores in the current window, starting at the given
+	// cursor. A returned cursor of 0 means there are no more members.
+	Top(ctx context.Context, count int64, cursor uint64) (members []ScoredMember, newCursor uint64, err error)
+}
+
+// leaderboard is the Leaderboard returned by NewLeaderboard. Scores are stored in one sorted set per time bucket, all
+// sharing a hash tag so they live in the same cluster slot.
+type leaderboard struct {
+	client *Client
+	name   string
+	config LeaderboardConfig
+	now    func() time.Time
+}
+
+// NewLeaderboard returns a Leaderboard that stores its scores under keys derived from name.
+func (cli *Client) NewLeaderboard(name string, config LeaderboardConfig) (Leaderboard, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	if config.Decay == 0 {
+		config.Decay = 1
+	}
+
+	return &leaderboard{
+		client: cli,
+		name:   name,
+		config: config,
+		now:    time.Now,
+	}, nil
+}
+
+// Validate will validate that the leaderboard config describes a usable window
+func (c *LeaderboardConfig) Validate() error {
+	if c.BucketSize <= 0 {
+		return errors.New("bucket size must be greater than zero")
+	}
+
+	if c.Window < c.BucketSize {
+		return errors.New("window must not be smaller than bucket size")
+	}
+
+	if c.Decay < 0 || c.Decay > 1 {
+		return errors.New("decay must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// Increment adds by to the score of member in the current time bucket.
+func (l *leaderboard) Increment(ctx context.Context, member string, by float64) error {
+	key := l.bucketKey(l.currentBucket())
+
+	if err := l.client.redisClient.ZIncrBy(ctx, key, by, member).Err(); err != nil {
+		return fmt.Errorf("error incrementing leaderboard %s: %w", l.name, err)
+	}
+
+	// Buckets are kept until they have fully left the window
+	if err := l.client.redisClient.Expire(ctx, key, l.config.Window+l.config.BucketSize).Err(); err != nil {
+		return fmt.Errorf("error setting expiry on leaderboard %s: %w", l.name, err)
+	}
+
+	return nil
+}
+
+// Top returns up to count members with the highest decayed scores in the current window, starting at the given cursor.
+// A returned cursor of 0 means there are no more members, matching the pagination of GetKeyValuePairs.
+// The ranking is recalculated on every call, so members may move between pages if scores change while paginating.
+func (l *leaderboard) Top(ctx context.Context, count int64, cursor uint64) (members []ScoredMember, newCursor uint64, err error) {
+	if count <= 0 {
+		return nil, 0, errors.New("count must be greater than zero")
+	}
+
+	numBuckets := int(math.Ceil(float64(l.config.Window) / float64(l.config.BucketSize)))
+	keys := make([]string, numBuckets)
+	weights := make([]float64, numBuckets)
+
+	bucket := l.currentBucket()
+	for i := 0; i < numBuckets; i++ {
+		keys[i] = l.bucketKey(bucket.Add(-time.Duration(i) * l.config.BucketSize))
+		weights[i] = math.Pow(l.config.Decay, float64(i))
+	}
+
+	// Aggregate the buckets into a short-lived ranking that shares the buckets' hash slot
+	rankingKey := l.client.key(fmt.Sprintf("{%s}:ranking", l.name))
+
+	total, err := l.client.redisClient.ZUnionStore(ctx, rankingKey, &redis.ZStore{
+		Keys:      keys,
+		Weights:   weights,
+		Aggregate: "SUM",
+	}).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error aggregating leaderboard %s: %w", l.name, err)
+	}
+
+	if total == 0 {
+		return []ScoredMember{}, 0, nil
+	}
+
+	if err = l.client.redisClient.Expire(ctx, rankingKey, l.config.BucketSize).Err(); err != nil {
+		return nil, 0, fmt.Errorf("error setting expiry on leaderboard %s: %w", l.name, err)
+	}
+
+	start := int64(cursor) // #nosec G115 -- cursor is an offset previously returned by Top
+	zMembers, err := l.client.redisClient.ZRangeArgsWithScores(ctx, redis.ZRangeArgs{
+		Key:   rankingKey,
+		Start: start,
+		Stop:  start + count - 1,
+		Rev:   true,
+	}).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting range of leaderboard %s: %w", l.name, err)
+	}
+
+	members = toScoredMembers(zMembers)
+
+	if next := start + int64(len(members)); next < total {
+		newCursor = uint64(next) // #nosec G115 -- next is bounded by total which is positive
+	}
+
+	return members, newCursor, nil
+}
+
+// currentBucket returns the start time of the bucket that the current time falls in
+func (l *leaderboard) currentBucket() time.Time {
+	return l.now().UTC().Truncate(l.config.BucketSize)
+}
+
+// bucketKey returns the key of the sorted set holding scores for the bucket starting at the given time
+func (l *leaderboard) bucketKey(bucket time.Time) string {
+	return l.client.key(fmt.Sprintf("{%s}:%d", l.name, bucket.Unix()))
+}
@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewLeaderboard(t *testing.T) {
+	client := &Client{}
+
+	Convey("When a leaderboard is created with a valid config", t, func() {
+		board, err := client.NewLeaderboard("popular", LeaderboardConfig{Window: time.Hour, BucketSize: time.Minute})
+
+		Convey("Then decay defaults to none", func() {
+			So(err, ShouldBeNil)
+			So(board.(*leaderboard).config.Decay, ShouldEqual, 1)
+		})
+	})
+
+	Convey("When a leaderboard is created with a window smaller than its buckets", t, func() {
+		_, err := client.NewLeaderboard("popular", LeaderboardConfig{Window: time.Minute, BucketSize: time.Hour})
+
+		Convey("Then a validation error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestLeaderboard_Top(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	Convey("Given a leaderboard with a three bucket window and decay", t, func() {
+		var store *redis.ZStore
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			ZUnionStoreFunc: func(ctx context.Context, dest string, s *redis.ZStore) *redis.IntCmd {
+				store = s
+				cmd := redis.NewIntCmd(ctx, "zunionstore", dest)
+				cmd.SetVal(3)
+				return cmd
+			},
+			ExpireFunc: func(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+				cmd := redis.NewBoolCmd(ctx, "expire", key)
+				cmd.SetVal(true)
+				return cmd
+			},
+			ZRangeArgsWithScoresFunc: func(ctx context.Context, z redis.ZRangeArgs) *redis.ZSliceCmd {
+				all := []redis.Z{{Member: "a", Score: 3}, {Member: "b", Score: 2}, {Member: "c", Score: 1}}
+				start, stop := z.Start.(int64), z.Stop.(int64)+1
+				if stop > int64(len(all)) {
+					stop = int64(len(all))
+				}
+				cmd := redis.NewZSliceCmd(ctx, "zrange", z.Key)
+				cmd.SetVal(all[start:stop])
+				return cmd
+			},
+		}
+
+		client := &Client{
+			redisClient: mockRedisClient,
+		}
+
+		board, err := client.NewLeaderboard("popular", LeaderboardConfig{Window: 3 * time.Hour, BucketSize: time.Hour, Decay: 0.5})
+		So(err, ShouldBeNil)
+		board.(*leaderboard).now = func() time.Time { return now }
+
+		Convey("When the first page is requested", func() {
+			members, cursor, err := board.Top(ctx, 2, 0)
+
+			Convey("Then every bucket in the window is aggregated with decaying weights", func() {
+				So(err, ShouldBeNil)
+				So(mockRedisClient.ZUnionStoreCalls()[0].Dest, ShouldEqual, "{popular}:ranking")
+				So(store.Keys, ShouldResemble, []string{
+					"{popular}:1735732800",
+					"{popular}:1735729200",
+					"{popular}:1735725600",
+				})
+				So(store.Weights, ShouldResemble, []float64{1, 0.5, 0.25})
+			})
+
+			Convey("Then the top members are returned with a cursor to the next page", func() {
+				So(members, ShouldResemble, []ScoredMember{{Member: "a", Score: 3}, {Member: "b", Score: 2}})
+				So(cursor, ShouldEqual, 2)
+
+				members, cursor, err = board.Top(ctx, 2, cursor)
+				So(err, ShouldBeNil)
+				So(members, ShouldResemble, []ScoredMember{{Member: "c", Score: 1}})
+				So(cursor, ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a leaderboard", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			ZIncrByFunc: func(ctx context.Context, key string, increment float64, member string) *redis.FloatCmd {
+				cmd := redis.NewFloatCmd(ctx, "zincrby", key, increment, member)
+				cmd.SetVal(increment)
+				return cmd
+			},
+			ExpireFunc: func(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+				cmd := redis.NewBoolCmd(ctx, "expire", key)
+				cmd.SetVal(true)
+				return cmd
+			},
+		}
+
+		client := &Client{
+			redisClient: mockRedisClient,
+		}
+
+		board, err := client.NewLeaderboard("popular", LeaderboardConfig{Window: 3 * time.Hour, BucketSize: time.Hour})
+		So(err, ShouldBeNil)
+		board.(*leaderboard).now = func() time.Time { return now }
+
+		Convey("When a member is incremented", func() {
+			err := board.Increment(ctx, "a", 1)
+
+			Convey("Then the current bucket is incremented and expires once it leaves the window", func() {
+				So(err, ShouldBeNil)
+				So(mockRedisClient.ZIncrByCalls()[0].Key, ShouldEqual, "{popular}:1735732800")
+				So(mockRedisClient.ExpireCalls()[0].Expiration, ShouldEqual, 4*time.Hour)
+			})
+		})
+	})
+}
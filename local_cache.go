@@ -0,0 +1,387 @@
+package redis
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultLocalCacheMaxEntries = 10000
+	defaultLocalCacheTTL        = time.Minute
+)
+
+// LocalCacheConfig configures a LocalCache.
+type LocalCacheConfig struct {
+	// MaxEntries is the maximum number of values held in memory, the least recently used value is evicted when it is
+	// exceeded. Defaults to 10000.
+	MaxEntries int
+	// TTL is how long a value is served from memory before it is read from redis again. It bounds how stale a value
+	// can be if an invalidation is missed. Defaults to one minute.
+	TTL time.Duration
+	// InvalidationChannel is a pub/sub channel that keys to evict are published to. Writes made through the LocalCache
+	// publish the key to the channel, so every instance sharing the channel evicts it. If empty, values are only
+	// evicted by writes made through the same LocalCache or when their TTL expires.
+	InvalidationChannel string
+}
+
+// LocalCacheStats counts the outcome of reads from a LocalCache.
+type LocalCacheStats struct {
+	Hits          int64
+	Misses        int64
+	Evictions     int64
+	Invalidations int64
+}
+
+// LocalCache serves hot reads from an in-process LRU cache in front of redis, e.g. for navigation or taxonomy data
+// that is read on every request. Values are cached for at most the configured TTL and are evicted early when their
+// keys are published to the invalidation channel.
+type LocalCache interface {
+	// GetValue returns the value of key from memory if it is cached, otherwise it is read from redis and cached.
+	GetValue(ctx context.Context, key string, opts ...CallOption) (string, error)
+	// SetValue sets a key-value pair in redis, evicting the key from memory and publishing it to the invalidation channel.
+	SetValue(ctx context.Context, key string, value interface{}, expiration time.Duration, opts ...CallOption) error
+	// DeleteValue deletes key from redis, evicting it from memory and publishing it to the invalidation channel.
+	DeleteValue(ctx context.Context, key string, opts ...CallOption) error
+	// Invalidate evicts keys from memory without changing them in redis.
+	Invalidate(keys ...string)
+	// InvalidateAll evicts every key from memory.
+	InvalidateAll()
+	// Stats returns the number of hits, misses, evictions and invalidations since the cache was created.
+	Stats() LocalCacheStats
+	// Len returns the number of values held in memory.
+	Len() int
+	// Close unsubscribes from the invalidation channel and evicts every key from memory.
+	Close(ctx context.Context) error
+}
+
+// localCache is the LocalCache returned by NewLocalCache
+type localCache struct {
+	client *Client
+	config LocalCacheConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	// generation is incremented by every invalidation, so that a value read from redis is not cached if it may have
+	// been invalidated while it was being read
+	generation uint64
+
+	subscription *subscription
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	evictions     atomic.Int64
+	invalidations atomic.Int64
+}
+
+// localCacheEntry is a value held by a LocalCache
+type localCacheEntry struct {
+	key     string
+	value   string
+	expires time.Time
+}
+
+// NewLocalCache returns a LocalCache in front of the client, subscribing to the config's invalidation channel if one
+// is set. The subscription is closed by LocalCache.Close or when the client is closed.
+func (cli *Client) NewLocalCache(ctx context.Context, config LocalCacheConfig) (LocalCache, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	c := newLocalCache(cli, config)
+
+	if config.InvalidationChannel != "" {
+		pubsub := cli.redisClient.Subscribe(ctx)
+		subscription, err := cli.startSubscription(ctx, pubsub, pubsub.Subscribe, c.handleInvalidation,
+			[]string{config.InvalidationChannel})
+		if err != nil {
+			return nil, fmt.Errorf("error subscribing to invalidation channel: %w", err)
+		}
+		// Invalidations may have been missed while the subscription was disconnected
+		subscription.setOnReconnect(c.InvalidateAll)
+		c.subscription = subscription
+	}
+
+	return c, nil
+}
+
+// newLocalCache returns an empty LocalCache without an invalidation subscription
+func newLocalCache(cli *Client, config LocalCacheConfig) *localCache {
+	if config.MaxEntries == 0 {
+		config.MaxEntries = defaultLocalCacheMaxEntries
+	}
+
+	if config.TTL == 0 {
+		config.TTL = defaultLocalCacheTTL
+	}
+
+	return &localCache{
+		client:  cli,
+		config:  config,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Validate will validate that the local cache config is usable
+func (c *LocalCacheConfig) Validate() error {
+	if c.MaxEntries < 0 {
+		return errors.New("max entries cannot be negative")
+	}
+
+	if c.TTL < 0 {
+		return errors.New("ttl cannot be negative")
+	}
+
+	return nil
+}
+
+// GetValue returns the value of key from memory if it is cached, otherwise it is read from redis and cached.
+// Missing keys are not cached. While the invalidation subscription is disconnected the cache is bypassed, and it is
+// cleared when the subscription reconnects, as invalidations may have been missed. The call options are used when
+// reading from redis, and WithoutCache reads the value from redis without caching it.
+func (c *localCache) GetValue(ctx context.Context, key string, opts ...CallOption) (string, error) {
+	var o callOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.bypassCache {
+		return c.client.GetValue(ctx, key, opts...)
+	}
+
+	if !c.invalidationHealthy() {
+		c.InvalidateAll()
+		c.misses.Add(1)
+		return c.client.GetValue(ctx, key, opts...)
+	}
+
+	return c.getOrFetch(key, func() (string, error) {
+		return c.client.GetValue(ctx, key, opts...)
+	})
+}
+
+// getOrFetch returns the value of key from memory if it is cached, otherwise it is fetched and cached
+func (c *localCache) getOrFetch(key string, fetch func() (string, error)) (string, error) {
+	if val, ok := c.get(key); ok {
+		c.hits.Add(1)
+		return val, nil
+	}
+
+	c.misses.Add(1)
+
+	generation := c.currentGeneration()
+
+	val, err := fetch()
+	if err != nil {
+		return "", err
+	}
+
+	c.set(key, val, generation)
+
+	return val, nil
+}
+
+// SetValue sets a key-value pair in redis with an optional expiration time, evicting the key from memory and
+// publishing it to the invalidation channel. In fail-open mode a failure to set the value or to publish the key is
+// suppressed as with Client.SetValue, and the write and publish are retried together if they are buffered.
+func (c *localCache) SetValue(ctx context.Context, key string, value interface{}, expiration time.Duration, opts ...CallOption) error {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	write := func(ctx context.Context) error {
+		return c.setValue(ctx, key, value, expiration)
+	}
+
+	return c.client.suppressWrite(ctx, key, retry(ctx, write), write)
+}
+
+// setValue writes the value of key to redis and publishes the key to the invalidation channel
+func (c *localCache) setValue(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := c.client.setValue(ctx, key, value, expiration); err != nil {
+		return err
+	}
+
+	return c.publishInvalidation(ctx, key)
+}
+
+// DeleteValue deletes key from redis, evicting it from memory and publishing it to the invalidation channel. In
+// fail-open mode failures are suppressed as with SetValue.
+func (c *localCache) DeleteValue(ctx context.Context, key string, opts ...CallOption) error {
+	ctx, cancel := withCallOptions(ctx, opts)
+	defer cancel()
+
+	err := retry(ctx, func(ctx context.Context) error {
+		return c.deleteValue(ctx, key)
+	})
+	return c.client.suppressWrite(ctx, key, err, func(ctx context.Context) error {
+		if err := c.deleteValue(ctx, key); err != nil && !errors.Is(err, ErrKeyNotFound) {
+			return err
+		}
+		return nil
+	})
+}
+
+// deleteValue deletes key from redis and publishes the key to the invalidation channel
+func (c *localCache) deleteValue(ctx context.Context, key string) error {
+	if err := c.client.deleteValue(ctx, key); err != nil {
+		return err
+	}
+
+	return c.publishInvalidation(ctx, key)
+}
+
+// Invalidate evicts keys from memory without changing them in redis.
+func (c *localCache) Invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.remove(elem)
+			c.invalidations.Add(1)
+		}
+	}
+}
+
+// InvalidateAll evicts every key from memory.
+func (c *localCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+
+	if len(c.entries) == 0 {
+		return
+	}
+
+	c.invalidations.Add(int64(len(c.entries)))
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+// Stats returns the number of hits, misses, evictions and invalidations since the cache was created.
+func (c *localCache) Stats() LocalCacheStats {
+	return LocalCacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+}
+
+// Len returns the number of values held in memory.
+func (c *localCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// Close unsubscribes from the invalidation channel and evicts every key from memory.
+func (c *localCache) Close(ctx context.Context) error {
+	c.InvalidateAll()
+
+	if c.subscription == nil {
+		return nil
+	}
+
+	return c.subscription.Close(ctx)
+}
+
+// handleInvalidation evicts the key published to the invalidation channel
+func (c *localCache) handleInvalidation(ctx context.Context, msg PubSubMessage) {
+	c.Invalidate(msg.Payload)
+}
+
+// publishInvalidation evicts key from memory and publishes it to the invalidation channel
+func (c *localCache) publishInvalidation(ctx context.Context, key string) error {
+	c.Invalidate(key)
+
+	if c.config.InvalidationChannel == "" {
+		return nil
+	}
+
+	_, err := c.client.Publish(ctx, c.config.InvalidationChannel, key)
+	return err
+}
+
+// invalidationHealthy reports whether invalidations are being received, which is always true without a channel
+func (c *localCache) invalidationHealthy() bool {
+	if c.subscription == nil {
+		return true
+	}
+
+	healthy, _ := c.subscription.Healthy()
+	return healthy
+}
+
+// get returns the unexpired value of key, marking it as recently used
+func (c *localCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	entry := elem.Value.(*localCacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(elem)
+		return "", false
+	}
+
+	c.lru.MoveToFront(elem)
+
+	return entry.value, true
+}
+
+// currentGeneration returns the number of invalidations so far
+func (c *localCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// set caches the value of key read at the given generation, evicting the least recently used value if the cache is
+// full. The value is discarded if there has been an invalidation since it was read.
+func (c *localCache) set(key, value string, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	entry := &localCacheEntry{key: key, value: value, expires: c.now().Add(c.config.TTL)}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+
+	for len(c.entries) > c.config.MaxEntries {
+		c.remove(c.lru.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// remove deletes elem from the cache, the caller must hold c.mu
+func (c *localCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*localCacheEntry).key)
+}
@@ -0,0 +1,266 @@
+package redis
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewLocalCache(t *testing.T) {
+	ctx := context.Background()
+	client := &Client{}
+
+	Convey("When a local cache is created without limits", t, func() {
+		cache, err := client.NewLocalCache(ctx, LocalCacheConfig{})
+
+		Convey("Then the defaults are used", func() {
+			So(err, ShouldBeNil)
+			So(cache.(*localCache).config.MaxEntries, ShouldEqual, defaultLocalCacheMaxEntries)
+			So(cache.(*localCache).config.TTL, ShouldEqual, defaultLocalCacheTTL)
+		})
+	})
+
+	Convey("When a local cache is created with a negative ttl", t, func() {
+		_, err := client.NewLocalCache(ctx, LocalCacheConfig{TTL: -time.Second})
+
+		Convey("Then a validation error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestLocalCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	Convey("Given a local cache in front of a mocked Redis client", t, func() {
+		var onGet func()
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+				if onGet != nil {
+					onGet()
+				}
+				cmd := redis.NewStringCmd(ctx, "get", key)
+				if key == "missing" {
+					cmd.SetErr(redis.Nil)
+				} else {
+					cmd.SetVal("val_for_" + key)
+				}
+				return cmd
+			},
+			SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx, "set", key)
+				cmd.SetVal("OK")
+				return cmd
+			},
+			PublishFunc: func(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "publish", channel)
+				cmd.SetVal(2)
+				return cmd
+			},
+		}
+
+		client := &Client{redisClient: mockRedisClient}
+
+		created, err := client.NewLocalCache(ctx, LocalCacheConfig{MaxEntries: 2, TTL: time.Minute})
+		So(err, ShouldBeNil)
+		cache := created.(*localCache)
+		cache.now = func() time.Time { return now }
+
+		Convey("When a key is read twice", func() {
+			first, err := cache.GetValue(ctx, "nav")
+			So(err, ShouldBeNil)
+			second, err := cache.GetValue(ctx, "nav")
+			So(err, ShouldBeNil)
+
+			Convey("Then the second read is served from memory", func() {
+				So(first, ShouldEqual, "val_for_nav")
+				So(second, ShouldEqual, "val_for_nav")
+				So(mockRedisClient.GetCalls(), ShouldHaveLength, 1)
+				So(cache.Stats(), ShouldResemble, LocalCacheStats{Hits: 1, Misses: 1})
+			})
+		})
+
+		Convey("When a key is read again after its ttl", func() {
+			_, _ = cache.GetValue(ctx, "nav")
+			now = now.Add(time.Minute)
+			_, _ = cache.GetValue(ctx, "nav")
+
+			Convey("Then it is read from redis again", func() {
+				So(mockRedisClient.GetCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When a missing key is read", func() {
+			_, err := cache.GetValue(ctx, "missing")
+
+			Convey("Then ErrKeyNotFound is returned and nothing is cached", func() {
+				So(err, ShouldEqual, ErrKeyNotFound)
+				So(cache.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When more keys are read than the cache holds", func() {
+			_, _ = cache.GetValue(ctx, "a")
+			_, _ = cache.GetValue(ctx, "b")
+			_, _ = cache.GetValue(ctx, "a")
+			_, _ = cache.GetValue(ctx, "c")
+
+			Convey("Then the least recently used key is evicted", func() {
+				So(cache.Len(), ShouldEqual, 2)
+				So(cache.Stats().Evictions, ShouldEqual, 1)
+
+				_, _ = cache.GetValue(ctx, "a")
+				So(mockRedisClient.GetCalls(), ShouldHaveLength, 3)
+				_, _ = cache.GetValue(ctx, "b")
+				So(mockRedisClient.GetCalls(), ShouldHaveLength, 4)
+			})
+		})
+
+		Convey("When a key is invalidated while it is being read from redis", func() {
+			onGet = func() { cache.Invalidate("nav") }
+			_, err := cache.GetValue(ctx, "nav")
+
+			Convey("Then the value that was read is not cached", func() {
+				So(err, ShouldBeNil)
+				So(cache.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When a cached key is published to the invalidation channel", func() {
+			_, _ = cache.GetValue(ctx, "nav")
+			cache.handleInvalidation(ctx, PubSubMessage{Channel: "invalidate", Payload: "nav"})
+
+			Convey("Then it is evicted", func() {
+				So(cache.Len(), ShouldEqual, 0)
+				So(cache.Stats().Invalidations, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the invalidation subscription is disconnected", func() {
+			_, _ = cache.GetValue(ctx, "nav")
+			cache.subscription = &subscription{healthy: false}
+			_, _ = cache.GetValue(ctx, "nav")
+
+			Convey("Then the cache is cleared and bypassed", func() {
+				So(mockRedisClient.GetCalls(), ShouldHaveLength, 2)
+				So(cache.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the invalidation subscription reconnects without a read while it was disconnected", func() {
+			_, _ = cache.GetValue(ctx, "nav")
+			cache.subscription = &subscription{healthy: true}
+			cache.subscription.setOnReconnect(cache.InvalidateAll)
+
+			cache.subscription.setHealthy(false, errors.New("connection reset by peer"))
+			lenWhileDisconnected := cache.Len()
+			cache.subscription.setHealthy(true, nil)
+
+			Convey("Then the cache is cleared when the subscription is healthy again", func() {
+				So(lenWhileDisconnected, ShouldEqual, 1)
+				So(cache.Len(), ShouldEqual, 0)
+				So(cache.Stats().Invalidations, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a local cache with an invalidation channel", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+				cmd := redis.NewStringCmd(ctx, "get", key)
+				cmd.SetVal("val_for_" + key)
+				return cmd
+			},
+			SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx, "set", key)
+				cmd.SetVal("OK")
+				return cmd
+			},
+			DelFunc: func(ctx context.Context, keys ...string) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "del", keys[0])
+				cmd.SetVal(1)
+				return cmd
+			},
+			PublishFunc: func(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "publish", channel)
+				cmd.SetVal(2)
+				return cmd
+			},
+		}
+
+		cache := &localCache{
+			client:       &Client{redisClient: mockRedisClient},
+			config:       LocalCacheConfig{MaxEntries: 10, TTL: time.Minute, InvalidationChannel: "invalidate"},
+			now:          time.Now,
+			entries:      map[string]*list.Element{},
+			lru:          list.New(),
+			subscription: &subscription{healthy: true},
+		}
+
+		Convey("When a cached key is written through the cache", func() {
+			_, _ = cache.GetValue(ctx, "nav")
+			err := cache.SetValue(ctx, "nav", "new", 0)
+
+			Convey("Then it is evicted locally and published to the invalidation channel", func() {
+				So(err, ShouldBeNil)
+				So(cache.Len(), ShouldEqual, 0)
+				So(mockRedisClient.PublishCalls()[0].Channel, ShouldEqual, "invalidate")
+				So(mockRedisClient.PublishCalls()[0].Message, ShouldEqual, "nav")
+			})
+		})
+
+		Convey("When publishing fails", func() {
+			mockRedisClient.PublishFunc = func(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "publish", channel)
+				cmd.SetErr(errors.New("connection refused"))
+				return cmd
+			}
+
+			Convey("And a key is written and deleted in fail-open mode", func() {
+				setErr := cache.SetValue(ctx, "nav", "new", 0, WithFailOpenMode(true))
+				deleteErr := cache.DeleteValue(ctx, "nav", WithFailOpenMode(true))
+
+				Convey("Then the publish errors are suppressed as failed writes", func() {
+					So(setErr, ShouldBeNil)
+					So(deleteErr, ShouldBeNil)
+					So(cache.client.FailOpenStats().SuppressedWrites, ShouldEqual, 2)
+					So(cache.client.FailOpenStats().DroppedWrites, ShouldEqual, 2)
+				})
+			})
+
+			Convey("And a key is written without fail-open mode", func() {
+				err := cache.SetValue(ctx, "nav", "new", 0)
+
+				Convey("Then the publish error is returned", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldContainSubstring, "connection refused")
+					So(cache.client.FailOpenStats().SuppressedWrites, ShouldEqual, 0)
+				})
+			})
+		})
+
+		Convey("When setting the value fails in fail-open mode", func() {
+			mockRedisClient.SetFunc = func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx, "set", key)
+				cmd.SetErr(errors.New("connection refused"))
+				return cmd
+			}
+
+			err := cache.SetValue(ctx, "nav", "new", 0, WithFailOpenMode(true))
+
+			Convey("Then the error is suppressed and the key is not published", func() {
+				So(err, ShouldBeNil)
+				So(cache.client.FailOpenStats().SuppressedWrites, ShouldEqual, 1)
+				So(mockRedisClient.PublishCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"strings"
+)
+
+// key returns key within the client's namespace
+func (cli *Client) key(key string) string {
+	return cli.keyPrefix + key
+}
+
+// keys returns keys within the client's namespace
+func (cli *Client) keys(keys []string) []string {
+	if cli.keyPrefix == "" {
+		return keys
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = cli.key(key)
+	}
+
+	return prefixed
+}
+
+// stripKey removes the client's namespace from a key returned by redis
+func (cli *Client) stripKey(key string) string {
+	return strings.TrimPrefix(key, cli.keyPrefix)
+}
+
+// matchPattern returns a SCAN match pattern that only matches keys within the client's namespace
+func (cli *Client) matchPattern(pattern string) string {
+	return escapeGlob(cli.keyPrefix) + pattern
+}
+
+// escapeGlob escapes the characters that have a special meaning in redis glob-style patterns
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testPrefix = "search:"
+
+func TestClient_KeyPrefix(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a client with a key prefix", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+				cmd := redis.NewStringCmd(ctx, "get", key)
+				cmd.SetVal(TestValue)
+				return cmd
+			},
+			SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx, "set", key)
+				cmd.SetVal("OK")
+				return cmd
+			},
+			ScanFunc: func(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+				cmd := redis.NewScanCmd(ctx, nil, "scan", cursor)
+				if cursor == 0 {
+					cmd.SetVal([]string{testPrefix + "key1", testPrefix + "key2"}, 7)
+				} else {
+					cmd.SetVal([]string{testPrefix + "key3"}, 0)
+				}
+				return cmd
+			},
+			MGetFunc: func(ctx context.Context, keys ...string) *redis.SliceCmd {
+				cmd := redis.NewSliceCmd(ctx, "mget")
+				vals := make([]interface{}, len(keys))
+				for i, key := range keys {
+					vals[i] = "val_for_" + key
+				}
+				cmd.SetVal(vals)
+				return cmd
+			},
+		}
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{KeyPrefix: testPrefix}, mockRedisClient)
+
+		Convey("When a value is set and read", func() {
+			So(client.SetValue(ctx, TestKey, TestValue, 0), ShouldBeNil)
+			_, err := client.GetValue(ctx, TestKey)
+			So(err, ShouldBeNil)
+
+			Convey("Then the prefixed key is used", func() {
+				So(mockRedisClient.SetCalls()[0].Key, ShouldEqual, testPrefix+TestKey)
+				So(mockRedisClient.GetCalls()[0].Key, ShouldEqual, testPrefix+TestKey)
+			})
+		})
+
+		Convey("When key-value pairs are scanned", func() {
+			results, _, err := client.GetKeyValuePairs(ctx, "key*", 10, 0)
+
+			Convey("Then only the namespace is matched and the prefix is removed from returned keys", func() {
+				So(err, ShouldBeNil)
+				So(mockRedisClient.ScanCalls()[0].Match, ShouldEqual, testPrefix+"key*")
+				So(mockRedisClient.MGetCalls()[0].Keys, ShouldResemble, []string{testPrefix + "key1", testPrefix + "key2"})
+				So(results, ShouldResemble, map[string]string{
+					"key1": "val_for_" + testPrefix + "key1",
+					"key2": "val_for_" + testPrefix + "key2",
+				})
+			})
+		})
+
+		Convey("When the total number of keys is requested", func() {
+			total, err := client.GetTotalKeys(ctx)
+
+			Convey("Then only the keys in the namespace are counted", func() {
+				So(err, ShouldBeNil)
+				So(total, ShouldEqual, 3)
+				So(mockRedisClient.DBSizeCalls(), ShouldBeEmpty)
+				So(mockRedisClient.ScanCalls()[0].Match, ShouldEqual, testPrefix+"*")
+			})
+		})
+	})
+
+	Convey("Given a client with a key prefix containing pattern characters", t, func() {
+		client := &Client{keyPrefix: "svc[1]*:"}
+
+		Convey("When a match pattern is built", func() {
+			pattern := client.matchPattern("*")
+
+			Convey("Then the prefix is matched literally", func() {
+				So(pattern, ShouldEqual, `svc\[1\]\*:*`)
+			})
+		})
+	})
+}
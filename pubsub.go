@@ -0,0 +1,315 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	subscriptionBufferSize = 100
+	subscriptionRetryWait  = time.Second
+)
+
+// subscriptionHealthCheckInterval is how long a subscription waits for a message before pinging the server
+// to check that its connection is still alive
+var subscriptionHealthCheckInterval = 30 * time.Second
+
+// PubSubMessage is a message received on a subscribed channel. Pattern is only set for pattern subscriptions.
+type PubSubMessage struct {
+	Channel string
+	Pattern string
+	Payload string
+}
+
+// PubSubHandler processes a message received on a subscription.
+type PubSubHandler func(ctx context.Context, msg PubSubMessage)
+
+// pubSubConn is the subset of *redis.PubSub used by a Subscription
+type pubSubConn interface {
+	ReceiveTimeout(ctx context.Context, timeout time.Duration) (interface{}, error)
+	Ping(ctx context.Context, payload ...string) error
+	Close() error
+}
+
+// Subscription receives messages published to one or more channels. If the connection to redis is lost the
+// subscription reconnects, re-authenticating if IAM authentication is in use, and resubscribes automatically.
+type Subscription interface {
+	// Messages returns the channel that messages are delivered on when the subscription has no handler.
+	Messages() <-chan PubSubMessage
+	// Healthy reports whether the subscription is currently connected, along with the last error if it is not.
+	Healthy() (bool, error)
+	// Close unsubscribes and waits for any in-flight handler to return, or for ctx to be done.
+	Close(ctx context.Context) error
+}
+
+// subscription is the Subscription returned by Subscribe, PSubscribe and SSubscribe
+type subscription struct {
+	client   *Client
+	conn     pubSubConn
+	channels []string
+	handler  PubSubHandler
+	messages chan PubSubMessage
+
+	mu      sync.Mutex
+	healthy bool
+	lastErr error
+	// onReconnect is called when the subscription becomes healthy again after being disconnected
+	onReconnect func()
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Publish posts message to channel and returns the number of clients that received it.
+func (cli *Client) Publish(ctx context.Context, channel string, message interface{}) (int64, error) {
+	receivers, err := cli.redisClient.Publish(ctx, channel, message).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error publishing to channel %s: %w", channel, err)
+	}
+
+	return receivers, nil
+}
+
+// PublishSharded posts message to the shard channel with SPUBLISH and returns the number of clients that received it.
+// On cluster clients the message is only propagated within the shard that owns the channel's slot.
+func (cli *Client) PublishSharded(ctx context.Context, channel string, message interface{}) (int64, error) {
+	receivers, err := cli.redisClient.SPublish(ctx, channel, message).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error publishing to shard channel %s: %w", channel, err)
+	}
+
+	return receivers, nil
+}
+
+// Subscribe subscribes to the given channels. Messages are passed to handler if one is provided,
+// otherwise they are delivered on the subscription's Messages channel.
+func (cli *Client) Subscribe(ctx context.Context, handler PubSubHandler, channels ...string) (Subscription, error) {
+	pubsub := cli.redisClient.Subscribe(ctx)
+	return subscribed(cli.startSubscription(ctx, pubsub, pubsub.Subscribe, handler, channels))
+}
+
+// PSubscribe subscribes to channels matching the given patterns. Messages are passed to handler if one is provided,
+// otherwise they are delivered on the subscription's Messages channel.
+func (cli *Client) PSubscribe(ctx context.Context, handler PubSubHandler, patterns ...string) (Subscription, error) {
+	pubsub := cli.redisClient.PSubscribe(ctx)
+	return subscribed(cli.startSubscription(ctx, pubsub, pubsub.PSubscribe, handler, patterns))
+}
+
+// SSubscribe subscribes to the given shard channels with SSUBSCRIBE. On cluster clients all channels must hash to the
+// same slot. Messages are passed to handler if one is provided, otherwise they are delivered on the subscription's
+// Messages channel.
+func (cli *Client) SSubscribe(ctx context.Context, handler PubSubHandler, channels ...string) (Subscription, error) {
+	pubsub := cli.redisClient.SSubscribe(ctx)
+	return subscribed(cli.startSubscription(ctx, pubsub, pubsub.SSubscribe, handler, channels))
+}
+
+// subscribed returns the result of startSubscription as a Subscription, which is nil rather than a nil *subscription
+// if it failed
+func subscribed(s *subscription, err error) (Subscription, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// startSubscription subscribes conn to channels, waits for the server to confirm and then starts receiving messages
+func (cli *Client) startSubscription(ctx context.Context, conn pubSubConn, subscribe func(context.Context, ...string) error,
+	handler PubSubHandler, channels []string) (*subscription, error) {
+	if len(channels) == 0 {
+		_ = conn.Close()
+		return nil, errors.New("at least one channel must be provided")
+	}
+
+	if err := subscribe(ctx, channels...); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("error subscribing to %v: %w", channels, err)
+	}
+
+	reply, err := conn.ReceiveTimeout(ctx, 0)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("error subscribing to %v: %w", channels, err)
+	}
+
+	if _, ok := reply.(*redis.Subscription); !ok {
+		_ = conn.Close()
+		return nil, fmt.Errorf("error subscribing to %v: unexpected reply %v", channels, reply)
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	s := &subscription{
+		client:   cli,
+		conn:     conn,
+		channels: channels,
+		handler:  handler,
+		healthy:  true,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	if handler == nil {
+		s.messages = make(chan PubSubMessage, subscriptionBufferSize)
+	}
+
+	cli.addSubscription(s)
+
+	go s.run(runCtx)
+
+	return s, nil
+}
+
+// Messages returns the channel that messages are delivered on when the subscription has no handler.
+// The channel is closed when the subscription is closed. It returns nil if the subscription has a handler.
+func (s *subscription) Messages() <-chan PubSubMessage {
+	return s.messages
+}
+
+// Healthy reports whether the subscription is currently connected, along with the last error if it is not.
+func (s *subscription) Healthy() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.healthy, s.lastErr
+}
+
+// setOnReconnect sets the function called when the subscription reconnects after being disconnected
+func (s *subscription) setOnReconnect(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onReconnect = fn
+}
+
+// Close unsubscribes and waits for any in-flight handler to return, or for ctx to be done.
+func (s *subscription) Close(ctx context.Context) error {
+	s.client.removeSubscription(s)
+	s.cancel()
+
+	if err := s.conn.Close(); err != nil && !errors.Is(err, redis.ErrClosed) {
+		return fmt.Errorf("error closing subscription to %v: %w", s.channels, err)
+	}
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for subscription to %v to close: %w", s.channels, ctx.Err())
+	}
+}
+
+// run receives messages until ctx is done. Errors mark the subscription as unhealthy and the next receive
+// reconnects and resubscribes.
+func (s *subscription) run(ctx context.Context) {
+	defer close(s.done)
+	if s.messages != nil {
+		defer close(s.messages)
+	}
+
+	for ctx.Err() == nil {
+		reply, err := s.conn.ReceiveTimeout(ctx, subscriptionHealthCheckInterval)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				// Nothing has been received for a while, so check the connection is still alive
+				if err = s.conn.Ping(ctx); err == nil {
+					continue
+				}
+			}
+
+			s.setHealthy(false, err)
+			log.Error(ctx, "error receiving from subscription, will resubscribe", err, log.Data{"channels": s.channels})
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(subscriptionRetryWait):
+			}
+			continue
+		}
+
+		switch msg := reply.(type) {
+		case *redis.Subscription, *redis.Pong:
+			s.setHealthy(true, nil)
+		case *redis.Message:
+			s.setHealthy(true, nil)
+			s.deliver(ctx, PubSubMessage{Channel: msg.Channel, Pattern: msg.Pattern, Payload: msg.Payload})
+		}
+	}
+}
+
+// deliver passes msg to the handler or the messages channel
+func (s *subscription) deliver(ctx context.Context, msg PubSubMessage) {
+	if s.handler != nil {
+		s.handler(ctx, msg)
+		return
+	}
+
+	select {
+	case s.messages <- msg:
+	case <-ctx.Done():
+	}
+}
+
+// setHealthy records whether the subscription is connected. The reconnect callback runs before a reconnected
+// subscription is reported as healthy, so that nothing relying on it sees state from while it was disconnected.
+func (s *subscription) setHealthy(healthy bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if healthy && !s.healthy && s.onReconnect != nil {
+		s.onReconnect()
+	}
+
+	s.healthy = healthy
+	s.lastErr = err
+}
+
+// addSubscription registers a subscription so that it is health checked and closed along with the client
+func (cli *Client) addSubscription(s *subscription) {
+	cli.addCloser(s, s.Close)
+
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+
+	if cli.subscriptions == nil {
+		cli.subscriptions = make(map[*subscription]struct{})
+	}
+	cli.subscriptions[s] = struct{}{}
+}
+
+// removeSubscription deregisters a subscription
+func (cli *Client) removeSubscription(s *subscription) {
+	cli.removeCloser(s)
+
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+
+	delete(cli.subscriptions, s)
+}
+
+// unhealthySubscriptions returns the subscriptions that are currently disconnected
+func (cli *Client) unhealthySubscriptions() []*subscription {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+
+	var unhealthy []*subscription
+	for s := range cli.subscriptions {
+		if healthy, _ := s.Healthy(); !healthy {
+			unhealthy = append(unhealthy, s)
+		}
+	}
+
+	return unhealthy
+}
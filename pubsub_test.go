@@ -0,0 +1,206 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/fake"
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type fakeReply struct {
+	msg interface{}
+	err error
+}
+
+// fakePubSubConn is a pubSubConn that returns replies pushed onto its channel
+type fakePubSubConn struct {
+	replies   chan fakeReply
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newFakePubSubConn() *fakePubSubConn {
+	return &fakePubSubConn{
+		replies: make(chan fakeReply, 10),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (f *fakePubSubConn) ReceiveTimeout(ctx context.Context, timeout time.Duration) (interface{}, error) {
+	select {
+	case r := <-f.replies:
+		return r.msg, r.err
+	case <-f.closed:
+		return nil, redis.ErrClosed
+	}
+}
+
+func (f *fakePubSubConn) Ping(ctx context.Context, payload ...string) error {
+	return nil
+}
+
+func (f *fakePubSubConn) Close() error {
+	f.closeOnce.Do(func() { close(f.closed) })
+	return nil
+}
+
+func TestClient_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mocked Redis client", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			PublishFunc: func(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "publish", channel, message)
+				cmd.SetVal(2)
+				return cmd
+			},
+			SPublishFunc: func(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "spublish", channel, message)
+				cmd.SetErr(errors.New("connection error"))
+				return cmd
+			},
+		}
+
+		client := &Client{
+			redisClient: mockRedisClient,
+		}
+
+		Convey("When a message is published", func() {
+			receivers, err := client.Publish(ctx, "invalidate", "key")
+
+			Convey("Then the number of receivers is returned", func() {
+				So(err, ShouldBeNil)
+				So(receivers, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When publishing to a shard channel fails", func() {
+			_, err := client.PublishSharded(ctx, "invalidate", "key")
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "connection error")
+			})
+		})
+	})
+}
+
+func TestSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a subscription without a handler", t, func() {
+		conn := newFakePubSubConn()
+		conn.replies <- fakeReply{msg: &redis.Subscription{Kind: "subscribe", Channel: "invalidate", Count: 1}}
+
+		client := &Client{}
+		var subscribed []string
+		sub, err := client.startSubscription(ctx, conn, func(ctx context.Context, channels ...string) error {
+			subscribed = channels
+			return nil
+		}, nil, []string{"invalidate"})
+		So(err, ShouldBeNil)
+		So(subscribed, ShouldResemble, []string{"invalidate"})
+
+		Convey("When a message is published", func() {
+			conn.replies <- fakeReply{msg: &redis.Message{Channel: "invalidate", Payload: "key1"}}
+
+			Convey("Then it is delivered on the messages channel", func() {
+				So(<-sub.Messages(), ShouldResemble, PubSubMessage{Channel: "invalidate", Payload: "key1"})
+				So(sub.Close(ctx), ShouldBeNil)
+			})
+		})
+
+		Convey("When the connection is lost", func() {
+			var reconnects atomic.Int32
+			sub.setOnReconnect(func() { reconnects.Add(1) })
+
+			connErr := errors.New("connection reset by peer")
+			conn.replies <- fakeReply{err: connErr}
+
+			Convey("Then the subscription reports it is unhealthy until it has resubscribed", func() {
+				So(waitFor(func() bool { healthy, _ := sub.Healthy(); return !healthy }), ShouldBeTrue)
+				_, lastErr := sub.Healthy()
+				So(lastErr, ShouldEqual, connErr)
+				So(client.unhealthySubscriptions(), ShouldHaveLength, 1)
+
+				conn.replies <- fakeReply{msg: &redis.Subscription{Kind: "subscribe", Channel: "invalidate", Count: 1}}
+				So(waitFor(func() bool { healthy, _ := sub.Healthy(); return healthy }), ShouldBeTrue)
+				So(client.unhealthySubscriptions(), ShouldBeEmpty)
+				So(reconnects.Load(), ShouldEqual, 1)
+
+				So(sub.Close(ctx), ShouldBeNil)
+			})
+		})
+
+		Convey("When the client is closed", func() {
+			client.redisClient = &mocks.GoRedisClientMock{CloseFunc: func() error { return nil }}
+			So(client.Close(ctx), ShouldBeNil)
+
+			Convey("Then the subscription is closed and its messages channel is closed", func() {
+				_, open := <-sub.Messages()
+				So(open, ShouldBeFalse)
+				So(client.subscriptions, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a subscription with a handler", t, func() {
+		conn := newFakePubSubConn()
+		conn.replies <- fakeReply{msg: &redis.Subscription{Kind: "psubscribe", Channel: "cache:*", Count: 1}}
+
+		received := make(chan PubSubMessage, 1)
+		client := &Client{}
+		sub, err := client.startSubscription(ctx, conn, func(ctx context.Context, channels ...string) error {
+			return nil
+		}, func(ctx context.Context, msg PubSubMessage) {
+			received <- msg
+		}, []string{"cache:*"})
+		So(err, ShouldBeNil)
+
+		Convey("When a message matching the pattern is published", func() {
+			conn.replies <- fakeReply{msg: &redis.Message{Pattern: "cache:*", Channel: "cache:nav", Payload: "key1"}}
+
+			Convey("Then it is passed to the handler", func() {
+				So(<-received, ShouldResemble, PubSubMessage{Pattern: "cache:*", Channel: "cache:nav", Payload: "key1"})
+				So(sub.Messages(), ShouldBeNil)
+				So(sub.Close(ctx), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("When subscribing fails", t, func() {
+		conn := newFakePubSubConn()
+		client := &Client{}
+		_, err := client.startSubscription(ctx, conn, func(ctx context.Context, channels ...string) error {
+			return errors.New("connection refused")
+		}, nil, []string{"invalidate"})
+
+		Convey("Then the error is returned and the connection closed", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "connection refused")
+			_, open := <-conn.closed
+			So(open, ShouldBeFalse)
+		})
+	})
+
+	Convey("When a client subscribes without any channels", t, func() {
+		srv := fake.NewServer()
+		defer srv.Close()
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, srv.NewClient())
+		defer client.Close(ctx)
+
+		sub, err := client.Subscribe(ctx, nil)
+
+		Convey("Then an error and a nil Subscription are returned", func() {
+			So(err, ShouldNotBeNil)
+			So(sub == nil, ShouldBeTrue)
+		})
+	})
+}
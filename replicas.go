@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// setReader makes the client send reads to reader, such as an ElastiCache reader endpoint, unless a call asks for the
+// primary. The reader is closed when the client is closed.
+func (cli *Client) setReader(reader redis.UniversalClient) {
+	cli.addHooks(reader)
+	cli.readerClient = reader
+	cli.replicaReads = true
+
+	cli.addCloser(reader, func(context.Context) error {
+		return reader.Close()
+	})
+}
+
+// readClient returns the client that a read of key made with ctx is sent to. Reads go to replicas if the client is
+// configured to read from them, unless the call asks for the primary with WithReplicaRead, and vice versa.
+func (cli *Client) readClient(ctx context.Context, key string) redis.UniversalClient {
+	replica := cli.replicaReads
+	if o := callOptionsFrom(ctx).replica; o != nil {
+		replica = *o
+	}
+
+	if replica {
+		if cli.readerClient != nil {
+			return cli.readerClient
+		}
+		return cli.redisClient
+	}
+
+	// Read only cluster clients route reads to replicas themselves, so the key's master is addressed directly
+	if cluster, isCluster := cli.redisClient.(*redis.ClusterClient); isCluster && cli.replicaReads && key != "" {
+		if master, err := cluster.MasterForKey(ctx, key); err == nil {
+			return master
+		}
+	}
+
+	return cli.redisClient
+}
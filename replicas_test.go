@@ -0,0 +1,146 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_ReaderEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a client with separate primary and reader endpoints", t, func() {
+		newEndpoint := func(name string) *mocks.GoRedisClientMock {
+			return &mocks.GoRedisClientMock{
+				GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+					cmd := redis.NewStringCmd(ctx, "get", key)
+					cmd.SetVal(name)
+					return cmd
+				},
+				SetFunc: func(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+					return redis.NewStatusCmd(ctx, "set", key)
+				},
+				ScanFunc: func(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+					cmd := redis.NewScanCmd(ctx, nil, "scan", cursor)
+					cmd.SetVal([]string{"key"}, 0)
+					return cmd
+				},
+				MGetFunc: func(ctx context.Context, keys ...string) *redis.SliceCmd {
+					cmd := redis.NewSliceCmd(ctx, "mget")
+					cmd.SetVal([]interface{}{name})
+					return cmd
+				},
+			}
+		}
+
+		primary := newEndpoint("primary")
+		reader := newEndpoint("reader")
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, primary)
+		client.setReader(reader)
+
+		Convey("When values are read", func() {
+			val, err := client.GetValue(ctx, "key")
+			pairs, _, pairsErr := client.GetKeyValuePairs(ctx, "*", 10, 0)
+
+			Convey("Then they are read from the reader", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "reader")
+				So(pairsErr, ShouldBeNil)
+				So(pairs, ShouldResemble, map[string]string{"key": "reader"})
+				So(primary.GetCalls(), ShouldBeEmpty)
+				So(primary.ScanCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a value is read from the primary for read-your-writes", func() {
+			val, err := client.GetValue(ctx, "key", WithReplicaRead(false))
+
+			Convey("Then it is read from the primary", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "primary")
+			})
+		})
+
+		Convey("When a value is written", func() {
+			err := client.SetValue(ctx, "key", "value", 0)
+
+			Convey("Then it is written to the primary", func() {
+				So(err, ShouldBeNil)
+				So(primary.SetCalls(), ShouldHaveLength, 1)
+				So(reader.SetCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a client without a reader endpoint", t, func() {
+		primary := &mocks.GoRedisClientMock{
+			GetFunc: func(ctx context.Context, key string) *redis.StringCmd {
+				cmd := redis.NewStringCmd(ctx, "get", key)
+				cmd.SetVal("primary")
+				return cmd
+			},
+		}
+		client := &Client{redisClient: primary}
+
+		Convey("When a value is read from a replica", func() {
+			val, err := client.GetValue(ctx, "key", WithReplicaRead(true))
+
+			Convey("Then it is read from the primary", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "primary")
+			})
+		})
+	})
+}
+
+func TestClient_ReadOnlyCluster(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a read only cluster client with a master and a replica", t, func() {
+		clusterClient := redis.NewClusterClient(&redis.ClusterOptions{
+			ReadOnly: true,
+			ClusterSlots: func(ctx context.Context) ([]redis.ClusterSlot, error) {
+				return []redis.ClusterSlot{
+					{Start: 0, End: clusterSlots - 1, Nodes: []redis.ClusterNode{{Addr: "master:6379"}, {Addr: "replica:6379"}}},
+				}, nil
+			},
+			NewClient: func(opt *redis.Options) *redis.Client {
+				client := redis.NewClient(opt)
+				client.AddHook(&stubHook{respond: func(cmd redis.Cmder) {
+					switch cmd := cmd.(type) {
+					case *redis.CommandsInfoCmd:
+						cmd.SetVal(map[string]*redis.CommandInfo{"get": {Name: "get", ReadOnly: true}})
+					case *redis.StringCmd:
+						cmd.SetVal(opt.Addr)
+					}
+				}})
+				return client
+			},
+		})
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{ReadOnly: true}, clusterClient)
+
+		Convey("When a value is read", func() {
+			val, err := client.GetValue(ctx, "key")
+
+			Convey("Then it is read from the replica", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "replica:6379")
+			})
+		})
+
+		Convey("When a value is read from the primary", func() {
+			val, err := client.GetValue(ctx, "key", WithReplicaRead(false))
+
+			Convey("Then it is read from the key's master", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "master:6379")
+			})
+		})
+	})
+}
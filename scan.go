@@ -0,0 +1,267 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultScanCount is the default COUNT hint sent with SCAN
+const defaultScanCount = 1000
+
+// CountKeys returns the number of keys matching pattern, within the client's namespace if it has a KeyPrefix.
+// Keys are counted with SCAN, across every master of a cluster client, using the client's ScanCount hint.
+// Counting stops with the context's error if ctx is done.
+//
+// SCAN can return a key more than once if the keyspace is resized while scanning, so the count is an estimate
+// for keyspaces that are changing.
+func (cli *Client) CountKeys(ctx context.Context, pattern string) (int64, error) {
+	var total atomic.Int64
+
+	err := cli.forEachNode(ctx, true, func(ctx context.Context, node redis.UniversalClient) error {
+		var cursor uint64
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			keys, next, err := node.Scan(ctx, cursor, cli.matchPattern(pattern), cli.scanCountHint()).Result()
+			if err != nil {
+				return err
+			}
+
+			total.Add(int64(len(keys)))
+
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error counting keys matching %s: %w", pattern, err)
+	}
+
+	return total.Load(), nil
+}
+
+// ScanOption configures ScanKeys and ScanKeyValues
+type ScanOption func(*scanOptions)
+
+type scanOptions struct {
+	deduplicate bool
+	keyType     string
+}
+
+// WithDeduplication skips keys that have already been returned by the scan. SCAN can return a key more than once if
+// the keyspace is resized while scanning; removing duplicates requires remembering every key returned so far.
+func WithDeduplication() ScanOption {
+	return func(opts *scanOptions) {
+		opts.deduplicate = true
+	}
+}
+
+// WithKeyType only returns keys of the given redis type, e.g. "string", "hash" or "list", using SCAN's TYPE filter
+func WithKeyType(keyType string) ScanOption {
+	return func(opts *scanOptions) {
+		opts.keyType = keyType
+	}
+}
+
+// ScanKeys returns a sequence of the keys matching pattern, within the client's namespace if it has a KeyPrefix.
+// The whole keyspace is scanned, across every master of a cluster client, a page at a time using the client's
+// ScanCount hint. The scan stops when the loop over the sequence ends early.
+//
+// If an error occurs, including ctx being done, it is yielded with an empty key and the sequence ends:
+//
+//	for key, err := range cli.ScanKeys(ctx, "dataset:*") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (cli *Client) ScanKeys(ctx context.Context, pattern string, opts ...ScanOption) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		err := cli.scanPages(ctx, pattern, opts, func(node redis.UniversalClient, keys []string) bool {
+			for _, key := range keys {
+				if !yield(cli.stripKey(key), nil) {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// ScanKeyValues returns a sequence of the keys matching pattern and their values, scanned in the same way as ScanKeys.
+// Keys that do not hold a string, or that are removed before their value is fetched, are skipped. Values are fetched
+// a page at a time, with one MGET per hash slot on cluster clients.
+//
+// The returned function reports the error that ended the sequence, if any, and should be checked once the loop ends:
+//
+//	values, scanErr := cli.ScanKeyValues(ctx, "dataset:*")
+//	for key, value := range values {
+//		...
+//	}
+//	if err := scanErr(); err != nil {
+//		return err
+//	}
+func (cli *Client) ScanKeyValues(ctx context.Context, pattern string, opts ...ScanOption) (iter.Seq2[string, string], func() error) {
+	var scanErr error
+
+	seq := func(yield func(string, string) bool) {
+		var fetchErr error
+
+		scanErr = cli.scanPages(ctx, pattern, opts, func(node redis.UniversalClient, keys []string) bool {
+			values, err := cli.getValues(ctx, node, keys)
+			if err != nil {
+				fetchErr = err
+				return false
+			}
+
+			for _, key := range keys {
+				key = cli.stripKey(key)
+				if val, ok := values[key]; ok && !yield(key, val) {
+					return false
+				}
+			}
+			return true
+		})
+		if scanErr == nil {
+			scanErr = fetchErr
+		}
+	}
+
+	return seq, func() error { return scanErr }
+}
+
+// scanPages scans every node for keys matching pattern, passing each non-empty page of keys to fn until fn returns
+// false, the scan completes, or an error occurs
+func (cli *Client) scanPages(ctx context.Context, pattern string, opts []ScanOption, fn func(node redis.UniversalClient, keys []string) bool) error {
+	var options scanOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var seen map[string]struct{}
+	if options.deduplicate {
+		seen = make(map[string]struct{})
+	}
+
+	nodes, err := cli.scanNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("error scanning keys matching %s: %w", pattern, err)
+	}
+
+	for _, node := range nodes {
+		var cursor uint64
+		for {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("error scanning keys matching %s: %w", pattern, err)
+			}
+
+			var cmd *redis.ScanCmd
+			if options.keyType != "" {
+				cmd = node.ScanType(ctx, cursor, cli.matchPattern(pattern), cli.scanCountHint(), options.keyType)
+			} else {
+				cmd = node.Scan(ctx, cursor, cli.matchPattern(pattern), cli.scanCountHint())
+			}
+
+			keys, next, err := cmd.Result()
+			if err != nil {
+				return fmt.Errorf("error scanning keys matching %s: %w", pattern, err)
+			}
+
+			if seen != nil {
+				keys = unseen(keys, seen)
+			}
+
+			if len(keys) > 0 && !fn(node, keys) {
+				return nil
+			}
+
+			if next == 0 {
+				break
+			}
+			cursor = next
+		}
+	}
+
+	return nil
+}
+
+// unseen returns the keys that are not in seen, adding them to it
+func unseen(keys []string, seen map[string]struct{}) []string {
+	filtered := keys[:0]
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		filtered = append(filtered, key)
+	}
+
+	return filtered
+}
+
+// scanPage scans one page of keys matching matchPattern from cursor, returning the node that was scanned, the keys
+// found and the cursor for the next page. Cluster clients walk every master in turn using node-aware cursors.
+func (cli *Client) scanPage(ctx context.Context, matchPattern string, count int64, cursor uint64) (redis.UniversalClient, []string, uint64, error) {
+	if cluster, isCluster := cli.redisClient.(*redis.ClusterClient); isCluster {
+		return cli.scanClusterPage(ctx, cluster, matchPattern, count, cursor)
+	}
+
+	node := cli.readClient(ctx, "")
+
+	keys, newCursor, err := node.Scan(ctx, cursor, cli.matchPattern(matchPattern), count).Result()
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("error scanning keys: %w", err)
+	}
+
+	return node, keys, newCursor, nil
+}
+
+// getValues fetches the string values of keys from node, keyed by the keys with the client's namespace removed
+func (cli *Client) getValues(ctx context.Context, node redis.UniversalClient, keys []string) (map[string]string, error) {
+	if len(keys) == 0 {
+		return map[string]string{}, nil
+	}
+
+	if _, isCluster := cli.redisClient.(*redis.ClusterClient); isCluster {
+		return cli.getValuesBySlot(ctx, node, keys)
+	}
+
+	values, err := node.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching values for keys: %w", err)
+	}
+
+	keyValuePairs := make(map[string]string, len(keys))
+	for i, key := range keys {
+		if i < len(values) {
+			if val, ok := values[i].(string); ok {
+				if val, err = cli.decodeValue(ctx, key, val); err != nil {
+					return nil, fmt.Errorf("error decoding value for key %s: %w", cli.stripKey(key), err)
+				}
+				keyValuePairs[cli.stripKey(key)] = val
+			}
+		}
+	}
+
+	return keyValuePairs, nil
+}
+
+// scanCountHint returns the COUNT hint to send with SCAN
+func (cli *Client) scanCountHint() int64 {
+	if cli.scanCount <= 0 {
+		return defaultScanCount
+	}
+
+	return cli.scanCount
+}
@@ -0,0 +1,187 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_CountKeys(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a redis client with keys spread over several scan pages", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			ScanFunc: func(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+				cmd := redis.NewScanCmd(ctx, nil, "scan", cursor)
+				switch cursor {
+				case 0:
+					cmd.SetVal([]string{"key1", "key2"}, 5)
+				case 5:
+					cmd.SetVal([]string{}, 9)
+				default:
+					cmd.SetVal([]string{"key3"}, 0)
+				}
+				return cmd
+			},
+		}
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{ScanCount: 50}, mockRedisClient)
+
+		Convey("When the keys matching a pattern are counted", func() {
+			count, err := client.CountKeys(ctx, "key*")
+
+			Convey("Then every page is counted using the configured count hint", func() {
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 3)
+				So(mockRedisClient.ScanCalls(), ShouldHaveLength, 3)
+				So(mockRedisClient.ScanCalls()[0].Match, ShouldEqual, "key*")
+				So(mockRedisClient.ScanCalls()[0].Count, ShouldEqual, 50)
+			})
+		})
+
+		Convey("When the context is cancelled", func() {
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err := client.CountKeys(cancelledCtx, "key*")
+
+			Convey("Then counting stops with the context error", func() {
+				So(err, ShouldWrap, context.Canceled)
+				So(mockRedisClient.ScanCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestClient_ScanKeys(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a redis client whose scan returns a key twice", t, func() {
+		scan := func(ctx context.Context, cursor uint64) *redis.ScanCmd {
+			cmd := redis.NewScanCmd(ctx, nil, "scan", cursor)
+			if cursor == 0 {
+				cmd.SetVal([]string{"key1", "key2"}, 5)
+			} else {
+				cmd.SetVal([]string{"key2", "key3"}, 0)
+			}
+			return cmd
+		}
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			ScanFunc: func(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+				return scan(ctx, cursor)
+			},
+			ScanTypeFunc: func(ctx context.Context, cursor uint64, match string, count int64, keyType string) *redis.ScanCmd {
+				return scan(ctx, cursor)
+			},
+			MGetFunc: func(ctx context.Context, keys ...string) *redis.SliceCmd {
+				cmd := redis.NewSliceCmd(ctx, "mget")
+				vals := make([]interface{}, len(keys))
+				for i, key := range keys {
+					if key != "key3" {
+						vals[i] = "val_for_" + key
+					}
+				}
+				cmd.SetVal(vals)
+				return cmd
+			},
+		}
+
+		client := &Client{redisClient: mockRedisClient}
+
+		Convey("When every key is ranged over", func() {
+			var keys []string
+			for key, err := range client.ScanKeys(ctx, "key*") {
+				So(err, ShouldBeNil)
+				keys = append(keys, key)
+			}
+
+			Convey("Then every page is returned including the duplicate", func() {
+				So(keys, ShouldResemble, []string{"key1", "key2", "key2", "key3"})
+				So(mockRedisClient.ScanCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When keys are ranged over with deduplication and a type filter", func() {
+			var keys []string
+			for key, err := range client.ScanKeys(ctx, "key*", WithDeduplication(), WithKeyType("string")) {
+				So(err, ShouldBeNil)
+				keys = append(keys, key)
+			}
+
+			Convey("Then each key is returned once and the type is sent with SCAN", func() {
+				So(keys, ShouldResemble, []string{"key1", "key2", "key3"})
+				So(mockRedisClient.ScanCalls(), ShouldBeEmpty)
+				So(mockRedisClient.ScanTypeCalls(), ShouldHaveLength, 2)
+				So(mockRedisClient.ScanTypeCalls()[0].KeyType, ShouldEqual, "string")
+			})
+		})
+
+		Convey("When the loop ends after the first key", func() {
+			for range client.ScanKeys(ctx, "key*") {
+				break
+			}
+
+			Convey("Then no further pages are scanned", func() {
+				So(mockRedisClient.ScanCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When key-value pairs are ranged over", func() {
+			results := make(map[string]string)
+			values, scanErr := client.ScanKeyValues(ctx, "key*", WithDeduplication())
+			for key, val := range values {
+				results[key] = val
+			}
+
+			Convey("Then the values of string keys are returned", func() {
+				So(scanErr(), ShouldBeNil)
+				So(results, ShouldResemble, map[string]string{
+					"key1": "val_for_key1",
+					"key2": "val_for_key2",
+				})
+				So(mockRedisClient.MGetCalls(), ShouldHaveLength, 2)
+				So(mockRedisClient.MGetCalls()[1].Keys, ShouldResemble, []string{"key3"})
+			})
+		})
+	})
+
+	Convey("Given a redis client whose scan fails", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			ScanFunc: func(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+				cmd := redis.NewScanCmd(ctx, nil, "scan", cursor)
+				cmd.SetErr(errors.New("scan failed"))
+				return cmd
+			},
+		}
+
+		client := &Client{redisClient: mockRedisClient}
+
+		Convey("When keys are ranged over", func() {
+			var errs []error
+			for _, err := range client.ScanKeys(ctx, "key*") {
+				errs = append(errs, err)
+			}
+
+			Convey("Then the error is yielded and the sequence ends", func() {
+				So(errs, ShouldHaveLength, 1)
+				So(errs[0].Error(), ShouldContainSubstring, "scan failed")
+			})
+		})
+
+		Convey("When key-value pairs are ranged over", func() {
+			values, scanErr := client.ScanKeyValues(ctx, "key*")
+			for range values {
+			}
+
+			Convey("Then the error is reported once the loop ends", func() {
+				So(scanErr(), ShouldNotBeNil)
+				So(scanErr().Error(), ShouldContainSubstring, "scan failed")
+			})
+		})
+	})
+}
@@ -0,0 +1,135 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrScriptNotFound = errors.New("script not registered")
+)
+
+// RegisterScript adds a Lua script to the client's registry under name, replacing any script already registered
+// with that name. Registered scripts are run with RunScript.
+func (cli *Client) RegisterScript(name, src string) {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+
+	if cli.scripts == nil {
+		cli.scripts = make(map[string]*redis.Script)
+	}
+	cli.scripts[name] = redis.NewScript(src)
+}
+
+// RegisterScriptsFS registers every file in fsys matching pattern, e.g. an embed.FS with the pattern "lua/*.lua".
+// Each script is registered under its file name without the extension.
+func (cli *Client) RegisterScriptsFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return fmt.Errorf("error matching script files: %w", err)
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("no script files match %s", pattern)
+	}
+
+	for _, file := range files {
+		src, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("error reading script file %s: %w", file, err)
+		}
+
+		name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		cli.RegisterScript(name, string(src))
+	}
+
+	return nil
+}
+
+// LoadScripts loads every registered script into the redis script cache with SCRIPT LOAD, on every node of a cluster,
+// so that the first call to RunScript does not need to send the script source.
+func (cli *Client) LoadScripts(ctx context.Context) error {
+	cli.mu.Lock()
+	scripts := make(map[string]*redis.Script, len(cli.scripts))
+	for name, script := range cli.scripts {
+		scripts[name] = script
+	}
+	cli.mu.Unlock()
+
+	for name, script := range scripts {
+		load := func(ctx context.Context, node redis.UniversalClient) error {
+			return script.Load(ctx, node).Err()
+		}
+
+		// EVALSHA can be routed to replicas, so scripts are loaded on every node
+		if err := cli.forEachNode(ctx, false, load); err != nil {
+			return fmt.Errorf("error loading script %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// RunScript runs the registered script called name with EVALSHA, falling back to EVAL if the script is not in the
+// redis script cache. A nil reply from the script is returned as a nil result rather than an error.
+func (cli *Client) RunScript(ctx context.Context, name string, keys []string, args ...interface{}) (interface{}, error) {
+	cli.mu.Lock()
+	script, ok := cli.scripts[name]
+	cli.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrScriptNotFound, name)
+	}
+
+	result, err := script.Run(ctx, cli.redisClient, cli.keys(keys), args...).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error running script %s: %w", name, err)
+	}
+
+	return result, nil
+}
+
+// LoadFunctionLibrary loads a Redis 7 function library with FUNCTION LOAD REPLACE, on every master of a cluster,
+// and returns the library name. Functions in the library are called with CallFunction.
+func (cli *Client) LoadFunctionLibrary(ctx context.Context, code string) (string, error) {
+	var (
+		mu      sync.Mutex
+		library string
+	)
+
+	// Functions are replicated from masters to their replicas, so only masters need loading
+	err := cli.forEachNode(ctx, true, func(ctx context.Context, node redis.UniversalClient) error {
+		name, err := node.FunctionLoadReplace(ctx, code).Result()
+		mu.Lock()
+		library = name
+		mu.Unlock()
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("error loading function library: %w", err)
+	}
+
+	return library, nil
+}
+
+// CallFunction calls a Redis 7 function with FCALL. A nil reply from the function is returned as a nil result rather
+// than an error.
+func (cli *Client) CallFunction(ctx context.Context, function string, keys []string, args ...interface{}) (interface{}, error) {
+	result, err := cli.redisClient.FCall(ctx, function, cli.keys(keys), args...).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error calling function %s: %w", function, err)
+	}
+
+	return result, nil
+}
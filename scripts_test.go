@@ -0,0 +1,136 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testScript = "return redis.call('GET', KEYS[1])"
+
+func TestClient_RegisterScriptsFS(t *testing.T) {
+	Convey("Given a file system containing Lua scripts", t, func() {
+		fsys := fstest.MapFS{
+			"lua/get.lua":    {Data: []byte(testScript)},
+			"lua/README.md":  {Data: []byte("not a script")},
+			"lua/incr.lua":   {Data: []byte("return redis.call('INCR', KEYS[1])")},
+			"other/skip.lua": {Data: []byte("return 1")},
+		}
+
+		client := &Client{}
+
+		Convey("When the scripts matching a pattern are registered", func() {
+			err := client.RegisterScriptsFS(fsys, "lua/*.lua")
+
+			Convey("Then each script is registered under its file name", func() {
+				So(err, ShouldBeNil)
+				So(client.scripts, ShouldHaveLength, 2)
+				So(client.scripts, ShouldContainKey, "get")
+				So(client.scripts, ShouldContainKey, "incr")
+			})
+		})
+
+		Convey("When no scripts match the pattern", func() {
+			err := client.RegisterScriptsFS(fsys, "scripts/*.lua")
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestClient_RunScript(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a redis server without the script cached", t, func() {
+		var commands []string
+
+		redisClient, _ := newStubbedClient(func(cmd redis.Cmder) {
+			commands = append(commands, cmd.Name())
+			switch cmd.Name() {
+			case "evalsha":
+				cmd.SetErr(testRedisError("NOSCRIPT No matching script. Please use EVAL."))
+			case "eval":
+				cmd.(*redis.Cmd).SetVal(TestValue)
+			case "script":
+				cmd.(*redis.StringCmd).SetVal(redis.NewScript(testScript).Hash())
+			}
+		})
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, redisClient)
+		client.RegisterScript("get", testScript)
+
+		Convey("When a registered script is run", func() {
+			result, err := client.RunScript(ctx, "get", []string{TestKey})
+
+			Convey("Then EVALSHA is tried before falling back to EVAL", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, TestValue)
+				So(commands, ShouldResemble, []string{"evalsha", "eval"})
+			})
+		})
+
+		Convey("When the scripts are preloaded", func() {
+			err := client.LoadScripts(ctx)
+
+			Convey("Then SCRIPT LOAD is sent", func() {
+				So(err, ShouldBeNil)
+				So(commands, ShouldResemble, []string{"script"})
+			})
+		})
+
+		Convey("When an unregistered script is run", func() {
+			_, err := client.RunScript(ctx, "missing", nil)
+
+			Convey("Then a script not found error is returned", func() {
+				So(errors.Is(err, ErrScriptNotFound), ShouldBeTrue)
+				So(commands, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestClient_Functions(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a Redis 7 server", t, func() {
+		var args [][]interface{}
+
+		redisClient, _ := newStubbedClient(func(cmd redis.Cmder) {
+			args = append(args, cmd.Args())
+			switch cmd.Name() {
+			case "function":
+				cmd.(*redis.StringCmd).SetVal("quota")
+			case "fcall":
+				cmd.SetErr(redis.Nil)
+			}
+		})
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, redisClient)
+
+		Convey("When a function library is loaded", func() {
+			library, err := client.LoadFunctionLibrary(ctx, "#!lua name=quota\n...")
+
+			Convey("Then it replaces any existing version and returns the library name", func() {
+				So(err, ShouldBeNil)
+				So(library, ShouldEqual, "quota")
+				So(args[0][:3], ShouldResemble, []interface{}{"function", "load", "replace"})
+			})
+		})
+
+		Convey("When a function returning nil is called", func() {
+			result, err := client.CallFunction(ctx, "check_quota", []string{"user1"}, 10)
+
+			Convey("Then a nil result is returned without an error", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldBeNil)
+				So(args[0], ShouldResemble, []interface{}{"fcall", "check_quota", 1, "user1", 10})
+			})
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddSetMembers adds the given members to the set stored at key and returns the number of members that were added.
+func (cli *Client) AddSetMembers(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	added, err := cli.redisClient.SAdd(ctx, cli.key(key), members...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error adding members to set %s: %w", key, err)
+	}
+
+	return added, nil
+}
+
+// RemoveSetMembers removes the given members from the set stored at key and returns the number of members that were removed.
+func (cli *Client) RemoveSetMembers(ctx context.Context, key string, members ...interface{}) (int64, error) {
+	removed, err := cli.redisClient.SRem(ctx, cli.key(key), members...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error removing members from set %s: %w", key, err)
+	}
+
+	return removed, nil
+}
+
+// GetSetMembers returns all the members of the set stored at key. A missing key is treated as an empty set.
+func (cli *Client) GetSetMembers(ctx context.Context, key string) ([]string, error) {
+	members, err := cli.redisClient.SMembers(ctx, cli.key(key)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting members of set %s: %w", key, err)
+	}
+
+	return members, nil
+}
+
+// IsSetMember reports whether member is a member of the set stored at key.
+func (cli *Client) IsSetMember(ctx context.Context, key string, member interface{}) (bool, error) {
+	isMember, err := cli.redisClient.SIsMember(ctx, cli.key(key), member).Result()
+	if err != nil {
+		return false, fmt.Errorf("error checking membership of set %s: %w", key, err)
+	}
+
+	return isMember, nil
+}
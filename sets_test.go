@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_Sets(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mocked Redis client holding a set", t, func() {
+		set := map[string]bool{"a": true}
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			SAddFunc: func(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "sadd", key)
+				added := int64(0)
+				for _, m := range members {
+					if !set[m.(string)] {
+						set[m.(string)] = true
+						added++
+					}
+				}
+				cmd.SetVal(added)
+				return cmd
+			},
+			SRemFunc: func(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "srem", key)
+				removed := int64(0)
+				for _, m := range members {
+					if set[m.(string)] {
+						delete(set, m.(string))
+						removed++
+					}
+				}
+				cmd.SetVal(removed)
+				return cmd
+			},
+			SMembersFunc: func(ctx context.Context, key string) *redis.StringSliceCmd {
+				cmd := redis.NewStringSliceCmd(ctx, "smembers", key)
+				members := make([]string, 0, len(set))
+				for m := range set {
+					members = append(members, m)
+				}
+				cmd.SetVal(members)
+				return cmd
+			},
+			SIsMemberFunc: func(ctx context.Context, key string, member interface{}) *redis.BoolCmd {
+				cmd := redis.NewBoolCmd(ctx, "sismember", key, member)
+				cmd.SetVal(set[member.(string)])
+				return cmd
+			},
+		}
+
+		client := &Client{
+			redisClient: mockRedisClient,
+		}
+
+		Convey("When members are added", func() {
+			added, err := client.AddSetMembers(ctx, TestKey, "a", "b", "c")
+
+			Convey("Then only the new members are counted", func() {
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 2)
+
+				members, err := client.GetSetMembers(ctx, TestKey)
+				So(err, ShouldBeNil)
+				So(members, ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When a member is removed", func() {
+			removed, err := client.RemoveSetMembers(ctx, TestKey, "a")
+
+			Convey("Then it is no longer a member of the set", func() {
+				So(err, ShouldBeNil)
+				So(removed, ShouldEqual, 1)
+
+				isMember, err := client.IsSetMember(ctx, TestKey, "a")
+				So(err, ShouldBeNil)
+				So(isMember, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a mocked Redis client that returns an error", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			SMembersFunc: func(ctx context.Context, key string) *redis.StringSliceCmd {
+				cmd := redis.NewStringSliceCmd(ctx, "smembers", key)
+				cmd.SetErr(errors.New("connection error"))
+				return cmd
+			},
+		}
+
+		client := &Client{
+			redisClient: mockRedisClient,
+		}
+
+		Convey("When getting the members of a set", func() {
+			members, err := client.GetSetMembers(ctx, TestKey)
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "connection error")
+				So(members, ShouldBeNil)
+			})
+		})
+	})
+}
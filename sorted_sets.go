@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ScoredMember is a member of a sorted set together with its score.
+type ScoredMember struct {
+	Member string
+	Score  float64
+}
+
+// AddSortedSetMembers adds the given members to the sorted set stored at key, updating the score of any
+// members that already exist. It returns the number of members that were newly added.
+func (cli *Client) AddSortedSetMembers(ctx context.Context, key string, members ...ScoredMember) (int64, error) {
+	zMembers := make([]redis.Z, len(members))
+	for i, m := range members {
+		zMembers[i] = redis.Z{Score: m.Score, Member: m.Member}
+	}
+
+	added, err := cli.redisClient.ZAdd(ctx, cli.key(key), zMembers...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error adding members to sorted set %s: %w", key, err)
+	}
+
+	return added, nil
+}
+
+// RemoveSortedSetMembers removes the given members from the sorted set stored at key and returns the number
+// of members that were removed.
+func (cli *Client) RemoveSortedSetMembers(ctx context.Context, key string, members ...string) (int64, error) {
+	args := make([]interface{}, len(members))
+	for i, m := range members {
+		args[i] = m
+	}
+
+	removed, err := cli.redisClient.ZRem(ctx, cli.key(key), args...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error removing members from sorted set %s: %w", key, err)
+	}
+
+	return removed, nil
+}
+
+// IncrementSortedSetScore increments the score of member in the sorted set stored at key and returns the new score.
+// The member is added with a score of increment if it does not already exist.
+func (cli *Client) IncrementSortedSetScore(ctx context.Context, key, member string, increment float64) (float64, error) {
+	score, err := cli.redisClient.ZIncrBy(ctx, cli.key(key), increment, member).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error incrementing score of %s in sorted set %s: %w", member, key, err)
+	}
+
+	return score, nil
+}
+
+// GetSortedSetRange returns the members, with scores, between the start and stop ranks (inclusive) of the sorted set
+// stored at key. Negative ranks count back from the highest ranked member. If reverse is true the members are ranked
+// from highest to lowest score.
+func (cli *Client) GetSortedSetRange(ctx context.Context, key string, start, stop int64, reverse bool) ([]ScoredMember, error) {
+	return cli.getSortedSetRange(ctx, redis.ZRangeArgs{
+		Key:   key,
+		Start: start,
+		Stop:  stop,
+		Rev:   reverse,
+	})
+}
+
+// GetSortedSetRangeByScore returns the members, with scores, whose scores lie between min and max of the sorted set
+// stored at key. min and max follow the redis syntax, so "-inf", "+inf" and exclusive bounds such as "(5" are accepted.
+// A count of zero returns all matching members after offset.
+func (cli *Client) GetSortedSetRangeByScore(ctx context.Context, key, minScore, maxScore string, offset, count int64, reverse bool) ([]ScoredMember, error) {
+	return cli.getSortedSetRange(ctx, redis.ZRangeArgs{
+		Key:     key,
+		Start:   minScore,
+		Stop:    maxScore,
+		ByScore: true,
+		Rev:     reverse,
+		Offset:  offset,
+		Count:   limitCount(offset, count),
+	})
+}
+
+// GetSortedSetRangeByLex returns the members of the sorted set stored at key that lie lexicographically between min
+// and max. All members are expected to share the same score. min and max follow the redis syntax, e.g. "[a", "(b", "-"
+// and "+". A count of zero returns all matching members after offset.
+func (cli *Client) GetSortedSetRangeByLex(ctx context.Context, key, minMember, maxMember string, offset, count int64, reverse bool) ([]string, error) {
+	members, err := cli.redisClient.ZRangeArgs(ctx, redis.ZRangeArgs{
+		Key:    cli.key(key),
+		Start:  minMember,
+		Stop:   maxMember,
+		ByLex:  true,
+		Rev:    reverse,
+		Offset: offset,
+		Count:  limitCount(offset, count),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting range of sorted set %s: %w", key, err)
+	}
+
+	return members, nil
+}
+
+// getSortedSetRange runs ZRANGE with the provided arguments and converts the result into ScoredMembers
+func (cli *Client) getSortedSetRange(ctx context.Context, args redis.ZRangeArgs) ([]ScoredMember, error) {
+	key := args.Key
+	args.Key = cli.key(key)
+
+	zMembers, err := cli.redisClient.ZRangeArgsWithScores(ctx, args).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error getting range of sorted set %s: %w", key, err)
+	}
+
+	return toScoredMembers(zMembers), nil
+}
+
+// limitCount converts a count of zero into the redis LIMIT value meaning "all remaining" when an offset is in use
+func limitCount(offset, count int64) int64 {
+	if count == 0 && offset != 0 {
+		return -1
+	}
+
+	return count
+}
+
+// toScoredMembers converts go-redis sorted set members into ScoredMembers
+func toScoredMembers(zMembers []redis.Z) []ScoredMember {
+	members := make([]ScoredMember, 0, len(zMembers))
+	for _, z := range zMembers {
+		member, ok := z.Member.(string)
+		if !ok {
+			member = fmt.Sprint(z.Member)
+		}
+		members = append(members, ScoredMember{Member: member, Score: z.Score})
+	}
+
+	return members
+}
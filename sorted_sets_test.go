@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_SortedSets(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mocked Redis client", t, func() {
+		var rangeArgs redis.ZRangeArgs
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			ZAddFunc: func(ctx context.Context, key string, members ...redis.Z) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "zadd", key)
+				cmd.SetVal(int64(len(members)))
+				return cmd
+			},
+			ZIncrByFunc: func(ctx context.Context, key string, increment float64, member string) *redis.FloatCmd {
+				cmd := redis.NewFloatCmd(ctx, "zincrby", key, increment, member)
+				cmd.SetVal(10 + increment)
+				return cmd
+			},
+			ZRangeArgsWithScoresFunc: func(ctx context.Context, z redis.ZRangeArgs) *redis.ZSliceCmd {
+				rangeArgs = z
+				cmd := redis.NewZSliceCmd(ctx, "zrange", z.Key)
+				cmd.SetVal([]redis.Z{{Member: "b", Score: 2}, {Member: "a", Score: 1}})
+				return cmd
+			},
+			ZRangeArgsFunc: func(ctx context.Context, z redis.ZRangeArgs) *redis.StringSliceCmd {
+				rangeArgs = z
+				cmd := redis.NewStringSliceCmd(ctx, "zrange", z.Key)
+				cmd.SetVal([]string{"apple", "banana"})
+				return cmd
+			},
+			ZRemFunc: func(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "zrem", key)
+				cmd.SetErr(errors.New("connection error"))
+				return cmd
+			},
+		}
+
+		client := &Client{
+			redisClient: mockRedisClient,
+		}
+
+		Convey("When members are added", func() {
+			added, err := client.AddSortedSetMembers(ctx, TestKey, ScoredMember{Member: "a", Score: 1}, ScoredMember{Member: "b", Score: 2})
+
+			Convey("Then the members are passed to ZADD with their scores", func() {
+				So(err, ShouldBeNil)
+				So(added, ShouldEqual, 2)
+				So(mockRedisClient.ZAddCalls()[0].Members, ShouldResemble, []redis.Z{{Score: 1, Member: "a"}, {Score: 2, Member: "b"}})
+			})
+		})
+
+		Convey("When a score is incremented", func() {
+			score, err := client.IncrementSortedSetScore(ctx, TestKey, "a", 5)
+
+			Convey("Then the new score is returned", func() {
+				So(err, ShouldBeNil)
+				So(score, ShouldEqual, 15)
+			})
+		})
+
+		Convey("When a reversed range by rank is requested", func() {
+			members, err := client.GetSortedSetRange(ctx, TestKey, 0, -1, true)
+
+			Convey("Then the scored members are returned in order", func() {
+				So(err, ShouldBeNil)
+				So(rangeArgs.Rev, ShouldBeTrue)
+				So(members, ShouldResemble, []ScoredMember{{Member: "b", Score: 2}, {Member: "a", Score: 1}})
+			})
+		})
+
+		Convey("When a range by score is requested with an offset and no count", func() {
+			_, err := client.GetSortedSetRangeByScore(ctx, TestKey, "-inf", "(5", 10, 0, false)
+
+			Convey("Then all remaining members after the offset are requested", func() {
+				So(err, ShouldBeNil)
+				So(rangeArgs.ByScore, ShouldBeTrue)
+				So(rangeArgs.Start, ShouldEqual, "-inf")
+				So(rangeArgs.Stop, ShouldEqual, "(5")
+				So(rangeArgs.Offset, ShouldEqual, 10)
+				So(rangeArgs.Count, ShouldEqual, -1)
+			})
+		})
+
+		Convey("When a range by lex is requested", func() {
+			members, err := client.GetSortedSetRangeByLex(ctx, TestKey, "[a", "(c", 0, 2, false)
+
+			Convey("Then the members are returned", func() {
+				So(err, ShouldBeNil)
+				So(rangeArgs.ByLex, ShouldBeTrue)
+				So(rangeArgs.Count, ShouldEqual, 2)
+				So(members, ShouldResemble, []string{"apple", "banana"})
+			})
+		})
+
+		Convey("When removing members fails", func() {
+			removed, err := client.RemoveSortedSetMembers(ctx, TestKey, "a")
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "connection error")
+				So(removed, ShouldEqual, 0)
+			})
+		})
+	})
+}
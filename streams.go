@@ -0,0 +1,371 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultStreamBatchSize     = 10
+	defaultStreamBlock         = 5 * time.Second
+	defaultStreamClaimMinIdle  = time.Minute
+	defaultStreamClaimInterval = 30 * time.Second
+	defaultStreamStartID       = "$"
+	streamRetryInterval        = time.Second
+)
+
+var (
+	ErrConsumerAlreadyStarted = errors.New("stream consumer already started")
+)
+
+// StreamMessage is a single entry read from a Redis stream.
+type StreamMessage struct {
+	ID     string
+	Stream string
+	Values map[string]interface{}
+}
+
+// StreamHandler processes a message read from a stream. A message is acknowledged when the handler returns nil,
+// otherwise it is left pending and redelivered once it has been idle for the consumer's ClaimMinIdle.
+type StreamHandler func(ctx context.Context, msg StreamMessage) error
+
+// StreamConsumerConfig configures a consumer group reader. Any value that is not provided uses a default value.
+type StreamConsumerConfig struct {
+	Stream   string
+	Group    string
+	Consumer string
+	// Concurrency is the number of messages handled at once. Defaults to 1.
+	Concurrency int
+	// BatchSize is the maximum number of messages read or claimed per call. Defaults to 10.
+	BatchSize int64
+	// Block is how long XREADGROUP waits for new messages. Defaults to 5 seconds.
+	Block time.Duration
+	// ClaimMinIdle is how long a message must be pending before another consumer claims it. Defaults to 1 minute.
+	ClaimMinIdle time.Duration
+	// ClaimInterval is how often pending messages are checked for claiming. Defaults to 30 seconds.
+	ClaimInterval time.Duration
+	// StartID is the ID the group starts reading from if it has to be created. Defaults to "$", new messages only.
+	StartID string
+}
+
+// StreamConsumer reads messages from a stream as part of a consumer group and passes them to a handler.
+type StreamConsumer interface {
+	// Start creates the consumer group if needed and starts reading messages in the background.
+	Start(ctx context.Context) error
+	// Stop stops reading new messages and waits for in-flight messages to be handled, or for ctx to be done.
+	Stop(ctx context.Context) error
+}
+
+// streamConsumer is the StreamConsumer returned by NewStreamConsumer
+type streamConsumer struct {
+	client  *Client
+	config  StreamConsumerConfig
+	handler StreamHandler
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// AddToStream appends values to the stream, creating it if it does not exist, and returns the ID of the new entry.
+// If maxLen is greater than zero the stream is approximately trimmed to that many entries.
+func (cli *Client) AddToStream(ctx context.Context, stream string, values map[string]interface{}, maxLen int64) (string, error) {
+	id, err := cli.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: cli.key(stream),
+		MaxLen: maxLen,
+		Approx: maxLen > 0,
+		Values: values,
+	}).Result()
+	if err != nil {
+		return "", fmt.Errorf("error adding to stream %s: %w", stream, err)
+	}
+
+	return id, nil
+}
+
+// NewStreamConsumer returns a StreamConsumer that passes messages to handler once started.
+func (cli *Client) NewStreamConsumer(config StreamConsumerConfig, handler StreamHandler) (StreamConsumer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	if handler == nil {
+		return nil, errors.New("handler must be provided")
+	}
+
+	config.setDefaults()
+
+	return &streamConsumer{
+		client:  cli,
+		config:  config,
+		handler: handler,
+	}, nil
+}
+
+// Validate will validate that compulsory values are provided in config
+func (c *StreamConsumerConfig) Validate() error {
+	if c.Stream == "" {
+		return errors.New("stream must be provided")
+	}
+
+	if c.Group == "" {
+		return errors.New("group must be provided")
+	}
+
+	if c.Consumer == "" {
+		return errors.New("consumer must be provided")
+	}
+
+	if c.Concurrency < 0 {
+		return errors.New("concurrency must not be negative")
+	}
+
+	return nil
+}
+
+// setDefaults applies default values to any settings that have not been provided
+func (c *StreamConsumerConfig) setDefaults() {
+	if c.Concurrency == 0 {
+		c.Concurrency = 1
+	}
+
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultStreamBatchSize
+	}
+
+	if c.Block <= 0 {
+		c.Block = defaultStreamBlock
+	}
+
+	if c.ClaimMinIdle <= 0 {
+		c.ClaimMinIdle = defaultStreamClaimMinIdle
+	}
+
+	if c.ClaimInterval <= 0 {
+		c.ClaimInterval = defaultStreamClaimInterval
+	}
+
+	if c.StartID == "" {
+		c.StartID = defaultStreamStartID
+	}
+}
+
+// Start creates the consumer group if needed and starts reading messages in the background.
+// The consumer runs until Stop is called, the provided context is cancelled, or the Client is closed, and can be
+// started again once it has stopped.
+func (c *streamConsumer) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		return ErrConsumerAlreadyStarted
+	}
+
+	err := c.client.redisClient.XGroupCreateMkStream(ctx, c.client.key(c.config.Stream), c.config.Group, c.config.StartID).Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("error creating consumer group %s: %w", c.config.Group, err)
+	}
+
+	// Handlers keep the caller's context values but are not cancelled when reading stops,
+	// so that in-flight messages can finish during a graceful shutdown
+	handlerCtx := context.WithoutCancel(ctx)
+	readCtx, cancel := context.WithCancel(ctx)
+
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	messages := make(chan StreamMessage)
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go func() {
+		defer readers.Done()
+		c.read(readCtx, messages)
+	}()
+	go func() {
+		defer readers.Done()
+		c.claim(readCtx, messages)
+	}()
+
+	var workers sync.WaitGroup
+	workers.Add(c.config.Concurrency)
+	for i := 0; i < c.config.Concurrency; i++ {
+		go func() {
+			defer workers.Done()
+			for msg := range messages {
+				c.handle(handlerCtx, msg)
+			}
+		}()
+	}
+
+	go func(done chan struct{}) {
+		readers.Wait()
+		close(messages)
+		workers.Wait()
+		close(done)
+
+		// If the consumer stopped because ctx was cancelled rather than by Stop, reset it so that it can be started again
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.done == done && c.cancel != nil {
+			c.cancel()
+			c.cancel = nil
+			c.client.removeCloser(c)
+		}
+	}(c.done)
+
+	c.client.addCloser(c, c.Stop)
+
+	return nil
+}
+
+// Stop stops reading new messages and waits for in-flight messages to be handled, or for ctx to be done.
+// Messages that were read but not handled remain pending and are claimed when the consumer group next runs.
+func (c *streamConsumer) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	cancel, done := c.cancel, c.done
+	c.cancel = nil
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	c.client.removeCloser(c)
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for stream consumer %s to stop: %w", c.config.Consumer, ctx.Err())
+	}
+}
+
+// read reads new messages for this consumer with XREADGROUP until ctx is done
+func (c *streamConsumer) read(ctx context.Context, messages chan<- StreamMessage) {
+	for ctx.Err() == nil {
+		streams, err := c.client.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.config.Group,
+			Consumer: c.config.Consumer,
+			Streams:  []string{c.client.key(c.config.Stream), ">"},
+			Count:    c.config.BatchSize,
+			Block:    c.config.Block,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		} else if err != nil {
+			c.logAndWait(ctx, "error reading from stream", err)
+			continue
+		}
+
+		for _, stream := range streams {
+			if !c.dispatch(ctx, stream.Messages, messages) {
+				return
+			}
+		}
+	}
+}
+
+// claim periodically takes ownership of messages that have been pending for longer than ClaimMinIdle
+func (c *streamConsumer) claim(ctx context.Context, messages chan<- StreamMessage) {
+	ticker := time.NewTicker(c.config.ClaimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		start := "0-0"
+		for {
+			claimed, next, err := c.client.redisClient.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+				Stream:   c.client.key(c.config.Stream),
+				Group:    c.config.Group,
+				Consumer: c.config.Consumer,
+				MinIdle:  c.config.ClaimMinIdle,
+				Start:    start,
+				Count:    c.config.BatchSize,
+			}).Result()
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Error(ctx, "error claiming pending stream messages", err, c.logData())
+				}
+				break
+			}
+
+			if !c.dispatch(ctx, claimed, messages) {
+				return
+			}
+
+			// XAUTOCLAIM returns 0-0 once the whole pending entries list has been scanned
+			if next == "0-0" || next == "" {
+				break
+			}
+			start = next
+		}
+	}
+}
+
+// dispatch passes messages to the workers, returning false if ctx is done before they are all accepted
+func (c *streamConsumer) dispatch(ctx context.Context, xMessages []redis.XMessage, messages chan<- StreamMessage) bool {
+	for _, m := range xMessages {
+		select {
+		case messages <- StreamMessage{ID: m.ID, Stream: c.config.Stream, Values: m.Values}:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+// handle runs the handler for a message and acknowledges it on success
+func (c *streamConsumer) handle(ctx context.Context, msg StreamMessage) {
+	if err := c.handler(ctx, msg); err != nil {
+		log.Error(ctx, "error handling stream message", err, c.logData("message_id", msg.ID))
+		return
+	}
+
+	if err := c.client.redisClient.XAck(ctx, c.client.key(c.config.Stream), c.config.Group, msg.ID).Err(); err != nil {
+		log.Error(ctx, "error acknowledging stream message", err, c.logData("message_id", msg.ID))
+	}
+}
+
+// logAndWait logs err and waits before retrying, unless ctx is done
+func (c *streamConsumer) logAndWait(ctx context.Context, event string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	log.Error(ctx, event, err, c.logData())
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(streamRetryInterval):
+	}
+}
+
+// logData returns the log data identifying this consumer, along with any additional key-value pairs
+func (c *streamConsumer) logData(keyValues ...interface{}) log.Data {
+	data := log.Data{
+		"stream":   c.config.Stream,
+		"group":    c.config.Group,
+		"consumer": c.config.Consumer,
+	}
+
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		data[fmt.Sprint(keyValues[i])] = keyValues[i+1]
+	}
+
+	return data
+}
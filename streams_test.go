@@ -0,0 +1,209 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testStream = "events"
+	testGroup  = "search"
+)
+
+func TestClient_AddToStream(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mocked Redis client", t, func() {
+		mockRedisClient := &mocks.GoRedisClientMock{
+			XAddFunc: func(ctx context.Context, a *redis.XAddArgs) *redis.StringCmd {
+				cmd := redis.NewStringCmd(ctx, "xadd", a.Stream)
+				cmd.SetVal("1-0")
+				return cmd
+			},
+		}
+
+		client := &Client{
+			redisClient: mockRedisClient,
+		}
+
+		Convey("When a message is added with a maximum length", func() {
+			id, err := client.AddToStream(ctx, testStream, map[string]interface{}{"event": "published"}, 1000)
+
+			Convey("Then the stream is approximately trimmed", func() {
+				So(err, ShouldBeNil)
+				So(id, ShouldEqual, "1-0")
+
+				args := mockRedisClient.XAddCalls()[0].A
+				So(args.Stream, ShouldEqual, testStream)
+				So(args.MaxLen, ShouldEqual, 1000)
+				So(args.Approx, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestNewStreamConsumer(t *testing.T) {
+	client := &Client{}
+	handler := func(ctx context.Context, msg StreamMessage) error { return nil }
+
+	Convey("When a stream consumer is created without a group", t, func() {
+		_, err := client.NewStreamConsumer(StreamConsumerConfig{Stream: testStream, Consumer: "pod-1"}, handler)
+
+		Convey("Then a validation error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("When a stream consumer is created with only compulsory values", t, func() {
+		consumer, err := client.NewStreamConsumer(StreamConsumerConfig{Stream: testStream, Group: testGroup, Consumer: "pod-1"}, handler)
+
+		Convey("Then the defaults are applied", func() {
+			So(err, ShouldBeNil)
+			So(consumer.(*streamConsumer).config.Concurrency, ShouldEqual, 1)
+			So(consumer.(*streamConsumer).config.BatchSize, ShouldEqual, defaultStreamBatchSize)
+			So(consumer.(*streamConsumer).config.StartID, ShouldEqual, defaultStreamStartID)
+		})
+	})
+}
+
+func TestStreamConsumer(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a consumer group with one new and one stale pending message", t, func() {
+		var reads, claims int32
+
+		mockRedisClient := &mocks.GoRedisClientMock{
+			XGroupCreateMkStreamFunc: func(ctx context.Context, stream, group, start string) *redis.StatusCmd {
+				cmd := redis.NewStatusCmd(ctx, "xgroup", "create", stream, group, start)
+				cmd.SetErr(errors.New("BUSYGROUP Consumer Group name already exists"))
+				return cmd
+			},
+			XReadGroupFunc: func(ctx context.Context, a *redis.XReadGroupArgs) *redis.XStreamSliceCmd {
+				cmd := redis.NewXStreamSliceCmd(ctx, "xreadgroup")
+				if atomic.AddInt32(&reads, 1) == 1 {
+					cmd.SetVal([]redis.XStream{{Stream: testStream, Messages: []redis.XMessage{
+						{ID: "1-0", Values: map[string]interface{}{"ok": "true"}},
+						{ID: "2-0", Values: map[string]interface{}{"ok": "false"}},
+					}}})
+					return cmd
+				}
+				<-ctx.Done()
+				cmd.SetErr(ctx.Err())
+				return cmd
+			},
+			XAutoClaimFunc: func(ctx context.Context, a *redis.XAutoClaimArgs) *redis.XAutoClaimCmd {
+				cmd := redis.NewXAutoClaimCmd(ctx, "xautoclaim")
+				if atomic.AddInt32(&claims, 1) == 1 {
+					cmd.SetVal([]redis.XMessage{{ID: "0-1", Values: map[string]interface{}{"ok": "true"}}}, "0-0")
+				} else {
+					cmd.SetVal([]redis.XMessage{}, "0-0")
+				}
+				return cmd
+			},
+			XAckFunc: func(ctx context.Context, stream, group string, ids ...string) *redis.IntCmd {
+				cmd := redis.NewIntCmd(ctx, "xack", stream, group)
+				cmd.SetVal(int64(len(ids)))
+				return cmd
+			},
+			CloseFunc: func() error {
+				return nil
+			},
+		}
+
+		client := &Client{
+			redisClient: mockRedisClient,
+		}
+
+		var mu sync.Mutex
+		var handled []string
+		handlerDone := make(chan struct{}, 3)
+
+		consumer, err := client.NewStreamConsumer(StreamConsumerConfig{
+			Stream:        testStream,
+			Group:         testGroup,
+			Consumer:      "pod-1",
+			Concurrency:   2,
+			ClaimInterval: 10 * time.Millisecond,
+		}, func(ctx context.Context, msg StreamMessage) error {
+			defer func() { handlerDone <- struct{}{} }()
+			mu.Lock()
+			handled = append(handled, msg.ID)
+			mu.Unlock()
+			if msg.Values["ok"] != "true" {
+				return errors.New("handler failed")
+			}
+			return nil
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When the consumer is started", func() {
+			So(consumer.Start(ctx), ShouldBeNil)
+
+			for i := 0; i < 3; i++ {
+				select {
+				case <-handlerDone:
+				case <-time.After(5 * time.Second):
+					t.Fatal("timed out waiting for messages to be handled")
+				}
+			}
+
+			Convey("Then it cannot be started again", func() {
+				So(consumer.Start(ctx), ShouldEqual, ErrConsumerAlreadyStarted)
+				So(consumer.Stop(ctx), ShouldBeNil)
+			})
+
+			Convey("Then new and claimed messages are handled and only successes are acknowledged", func() {
+				So(client.Close(ctx), ShouldBeNil)
+
+				mu.Lock()
+				So(handled, ShouldHaveLength, 3)
+				So(handled, ShouldContain, "0-1")
+				mu.Unlock()
+
+				acked := []string{}
+				for _, call := range mockRedisClient.XAckCalls() {
+					So(call.Group, ShouldEqual, testGroup)
+					acked = append(acked, call.Ids...)
+				}
+				So(acked, ShouldHaveLength, 2)
+				So(acked, ShouldContain, "1-0")
+				So(acked, ShouldContain, "0-1")
+				So(acked, ShouldNotContain, "2-0")
+			})
+
+			Convey("Then closing the client stops the consumer", func() {
+				So(client.Close(ctx), ShouldBeNil)
+				So(client.closers, ShouldBeEmpty)
+				So(consumer.Stop(ctx), ShouldBeNil)
+			})
+		})
+
+		Convey("When the context the consumer was started with is cancelled", func() {
+			startCtx, cancel := context.WithCancel(ctx)
+			So(consumer.Start(startCtx), ShouldBeNil)
+			cancel()
+
+			stopped := waitFor(func() bool {
+				c := consumer.(*streamConsumer)
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				return c.cancel == nil
+			})
+
+			Convey("Then the consumer is reset and can be started again", func() {
+				So(stopped, ShouldBeTrue)
+				So(client.closers, ShouldBeEmpty)
+				So(consumer.Start(ctx), ShouldBeNil)
+				So(consumer.Stop(ctx), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// stubHook answers every command itself, so a real go-redis client can be used without a redis server
+type stubHook struct {
+	mu        sync.Mutex
+	respond   func(cmd redis.Cmder)
+	pipelines [][]redis.Cmder
+}
+
+func (h *stubHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *stubHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.respond(cmd)
+		return cmd.Err()
+	}
+}
+
+func (h *stubHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		h.mu.Lock()
+		h.pipelines = append(h.pipelines, cmds)
+		h.mu.Unlock()
+
+		var firstErr error
+		for _, cmd := range cmds {
+			h.respond(cmd)
+			if err := cmd.Err(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+}
+
+// testRedisError is an error reply from a redis server, as recognised by redis.HasErrorPrefix
+type testRedisError string
+
+func (e testRedisError) Error() string {
+	return string(e)
+}
+
+func (e testRedisError) RedisError() {}
+
+// newStubbedClient returns a go-redis client whose commands are answered by respond
+func newStubbedClient(respond func(cmd redis.Cmder)) (*redis.Client, *stubHook) {
+	hook := &stubHook{respond: respond}
+	client := redis.NewClient(&redis.Options{Addr: testAddress})
+	client.AddHook(hook)
+	return client, hook
+}
+
+// waitFor polls condition until it is true or a timeout is reached
+func waitFor(condition func() bool) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
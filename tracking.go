@@ -0,0 +1,535 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// invalidationChannel is the channel redis publishes invalidations to for clients that redirect them
+const invalidationChannel = "__redis__:invalidate"
+
+// TrackingConfig enables server-assisted client-side caching. Values read with GetValue are cached in memory and
+// redis sends an invalidation when a cached key changes, using CLIENT TRACKING with the invalidations redirected to a
+// connection that is subscribed to them.
+type TrackingConfig struct {
+	// Broadcast receives invalidations for every key matching Prefixes, rather than only the keys this client has
+	// read. It uses more bandwidth but less memory on the server.
+	Broadcast bool
+	// Prefixes limits broadcast invalidations to keys starting with one of the prefixes, within the client's
+	// KeyPrefix. Only valid with Broadcast.
+	Prefixes []string
+	// MaxEntries is the maximum number of values held in memory. Defaults to 10000.
+	MaxEntries int
+	// TTL is how long a value is served from memory before it is read from redis again, bounding how stale a value
+	// can be if an invalidation is delayed. Defaults to one minute.
+	TTL time.Duration
+}
+
+// Validate will validate that the tracking config is usable
+func (c *TrackingConfig) Validate() error {
+	if len(c.Prefixes) > 0 && !c.Broadcast {
+		return errors.New("tracking prefixes can only be used in broadcast mode")
+	}
+
+	cacheConfig := c.localCacheConfig()
+	return cacheConfig.Validate()
+}
+
+// localCacheConfig returns the config of the cache holding tracked values
+func (c *TrackingConfig) localCacheConfig() LocalCacheConfig {
+	return LocalCacheConfig{MaxEntries: c.MaxEntries, TTL: c.TTL}
+}
+
+// tracker caches values read by a client and evicts them when redis reports that they have changed.
+//
+// Tracking is enabled per connection and its invalidations are only read when that connection is used, so tracked
+// reads are not sent through the client's pools. Instead every node values are read from has a trackingNode, with a
+// single connection for tracked reads whose invalidations are redirected to a connection subscribed to them.
+type tracker struct {
+	// options are the CLIENT TRACKING options after the redirect, such as BCAST
+	options  []interface{}
+	cache    *localCache
+	addHooks func(redis.UniversalClient)
+
+	// unavailable is set when the server does not support tracking, after which values are no longer cached
+	unavailable atomic.Bool
+
+	mu     sync.Mutex
+	nodes  map[string]*trackingNode
+	closed bool
+}
+
+// trackingNode receives the invalidations of the tracked reads made from one redis node
+type trackingNode struct {
+	tracker *tracker
+	addr    string
+	// reads is the connection tracked reads are made on, with its invalidations redirected to subscriber
+	reads      *redis.Client
+	subscriber *redis.Client
+	pubsub     *redis.PubSub
+	// redirectID is the client ID of the subscriber's current connection
+	redirectID atomic.Int64
+	// healthy is set while the reads connection's invalidations are being received
+	healthy atomic.Bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newTracker returns a tracker for keys within keyPrefix, whose cache is keyed by the full redis key. The connections
+// tracked reads are made on are given the client's hooks with addHooks.
+func newTracker(config TrackingConfig, keyPrefix string, addHooks func(redis.UniversalClient)) *tracker {
+	var options []interface{}
+	if config.Broadcast {
+		options = append(options, "bcast")
+
+		prefixes := config.Prefixes
+		if len(prefixes) == 0 && keyPrefix != "" {
+			prefixes = []string{""}
+		}
+		for _, prefix := range prefixes {
+			options = append(options, "prefix", keyPrefix+prefix)
+		}
+	}
+
+	return &tracker{
+		options:  options,
+		cache:    newLocalCache(nil, config.localCacheConfig()),
+		addHooks: addHooks,
+		nodes:    make(map[string]*trackingNode),
+	}
+}
+
+// disable stops caching values after the server has rejected tracking
+func (t *tracker) disable(ctx context.Context, err error) {
+	if t.unavailable.CompareAndSwap(false, true) {
+		log.Warn(ctx, "client side caching disabled as redis tracking is unavailable", log.Data{"error": err.Error()})
+	}
+
+	t.cache.InvalidateAll()
+}
+
+// enabled reports whether values are being cached
+func (t *tracker) enabled() bool {
+	return t != nil && !t.unavailable.Load()
+}
+
+// node returns the trackingNode for client, starting it if it has not been used before. It returns nil until the
+// node is receiving invalidations, and while it is disconnected, so that values are read without caching.
+func (t *tracker) node(client *redis.Client) *trackingNode {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+
+	addr := client.Options().Addr
+	n, exists := t.nodes[addr]
+	if !exists {
+		n = t.newNode(client)
+		t.nodes[addr] = n
+	}
+
+	if !n.healthy.Load() {
+		return nil
+	}
+
+	return n
+}
+
+// newNode starts receiving invalidations from the node client is connected to. Its connections are created from
+// client's options, and the subscriber uses RESP2 so that invalidations are received as pub/sub messages.
+func (t *tracker) newNode(client *redis.Client) *trackingNode {
+	n := &trackingNode{tracker: t, addr: client.Options().Addr, done: make(chan struct{})}
+
+	subscriberOptions := *client.Options()
+	subscriberOptions.Protocol = 2
+	subscriberOptions.OnConnect = chainOnConnect(subscriberOptions.OnConnect, n.onSubscriberConnect)
+	n.subscriber = redis.NewClient(&subscriberOptions)
+
+	// Tracking is lost when a connection closes, so tracked reads share one connection that is never reaped for
+	// being idle, and the cache is cleared whenever it is replaced
+	readsOptions := *client.Options()
+	readsOptions.PoolSize = 1
+	readsOptions.MinIdleConns = 0
+	readsOptions.ConnMaxIdleTime = -1
+	readsOptions.ConnMaxLifetime = 0
+	readsOptions.OnConnect = chainOnConnect(readsOptions.OnConnect, n.onReadsConnect)
+	n.reads = redis.NewClient(&readsOptions)
+	t.addHooks(n.reads)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+	n.pubsub = n.subscriber.Subscribe(ctx)
+
+	go n.run(ctx)
+
+	return n
+}
+
+// close stops receiving invalidations and evicts every value
+func (t *tracker) close(ctx context.Context) error {
+	t.mu.Lock()
+	t.closed = true
+	nodes := t.nodes
+	t.nodes = nil
+	t.mu.Unlock()
+
+	var errs []error
+	for _, n := range nodes {
+		if err := n.close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	t.cache.InvalidateAll()
+
+	return errors.Join(errs...)
+}
+
+// trackingArgs returns the CLIENT TRACKING command that redirects the reads connection's invalidations to the
+// subscriber's current connection
+func (n *trackingNode) trackingArgs() []interface{} {
+	args := []interface{}{"client", "tracking", "on", "redirect", n.redirectID.Load()}
+	return append(args, n.tracker.options...)
+}
+
+// onSubscriberConnect records the client ID of a new subscriber connection, so that invalidations can be redirected
+// to it once it has subscribed
+func (n *trackingNode) onSubscriberConnect(ctx context.Context, conn *redis.Conn) error {
+	id, err := conn.ClientID(ctx).Result()
+	if err != nil {
+		return fmt.Errorf("error getting client id: %w", err)
+	}
+
+	n.redirectID.Store(id)
+
+	return nil
+}
+
+// onReadsConnect enables tracking on a new reads connection. Keys read on a previous connection are no longer
+// tracked, so every value is evicted.
+func (n *trackingNode) onReadsConnect(ctx context.Context, conn *redis.Conn) error {
+	n.tracker.cache.InvalidateAll()
+
+	err := conn.Do(ctx, n.trackingArgs()...).Err()
+	if trackingUnsupported(err) {
+		n.tracker.disable(ctx, err)
+		return nil
+	}
+
+	return err
+}
+
+// redirect points the reads connection's invalidations at the subscriber's current connection, after it has
+// (re)subscribed. Invalidations may have been missed while the subscriber was disconnected, so every value is
+// evicted before the node is marked as healthy.
+func (n *trackingNode) redirect(ctx context.Context) error {
+	_, err := n.reads.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Do(ctx, "client", "tracking", "off")
+		pipe.Do(ctx, n.trackingArgs()...)
+		return nil
+	})
+	if trackingUnsupported(err) {
+		n.tracker.disable(ctx, err)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error redirecting tracking invalidations: %w", err)
+	}
+
+	n.tracker.cache.InvalidateAll()
+	n.healthy.Store(true)
+
+	return nil
+}
+
+// run subscribes to invalidations and evicts the keys they name until ctx is done. The subscription reconnects and
+// resubscribes after an error, and the reads connection is redirected each time it does.
+func (n *trackingNode) run(ctx context.Context) {
+	defer close(n.done)
+
+	if err := n.pubsub.Subscribe(ctx, invalidationChannel); err != nil {
+		n.fail(ctx, err)
+	}
+
+	for ctx.Err() == nil {
+		// The redirect is retried on the next health check, which is brought forward while it is failing
+		timeout := subscriptionHealthCheckInterval
+		if !n.healthy.Load() {
+			timeout = subscriptionRetryWait
+		}
+
+		reply, err := n.pubsub.ReceiveTimeout(ctx, timeout)
+		if err != nil {
+			n.handleError(ctx, err)
+			continue
+		}
+
+		switch msg := reply.(type) {
+		case *redis.Subscription:
+			if err := n.redirect(ctx); err != nil {
+				n.fail(ctx, err)
+			}
+		case *redis.Message:
+			n.tracker.cache.Invalidate(msg.PayloadSlice...)
+		}
+	}
+}
+
+// handleError handles an error receiving invalidations, retrying the subscription after a connection error
+func (n *trackingNode) handleError(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Redis sends an invalidation without keys when it flushes its keyspace, which go-redis cannot parse as a message
+	if strings.Contains(err.Error(), "unsupported pubsub message payload") {
+		n.tracker.cache.InvalidateAll()
+		return
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		// Nothing has been received for a while, so check both connections are still alive
+		if err = n.healthCheck(ctx); err == nil {
+			return
+		}
+	}
+
+	n.fail(ctx, err)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(subscriptionRetryWait):
+	}
+}
+
+// healthCheck pings the subscriber and reads connections, retrying the redirect if it previously failed. Pinging the
+// reads connection also stops it being closed for being idle by the server or anything between.
+func (n *trackingNode) healthCheck(ctx context.Context) error {
+	if err := n.pubsub.Ping(ctx); err != nil {
+		return fmt.Errorf("error pinging tracking subscriber: %w", err)
+	}
+
+	if !n.healthy.Load() {
+		if err := n.redirect(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := n.reads.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("error pinging tracked connection: %w", err)
+	}
+
+	return nil
+}
+
+// fail marks the node as unhealthy, so that values are read without caching until invalidations are received again
+func (n *trackingNode) fail(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	n.healthy.Store(false)
+	n.tracker.cache.InvalidateAll()
+
+	log.Error(ctx, "error receiving tracking invalidations, will resubscribe", err, log.Data{"address": n.addr})
+}
+
+// close stops receiving invalidations from the node and closes its connections
+func (n *trackingNode) close(ctx context.Context) error {
+	n.healthy.Store(false)
+	n.cancel()
+
+	errs := []error{n.pubsub.Close()}
+
+	select {
+	case <-n.done:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("error waiting for tracking of %s to stop: %w", n.addr, ctx.Err()))
+	}
+
+	errs = append(errs, n.subscriber.Close(), n.reads.Close())
+
+	return errors.Join(errs...)
+}
+
+// chainOnConnect returns an OnConnect hook that runs next after the hook of the options it was copied from, if any
+func chainOnConnect(prev, next func(context.Context, *redis.Conn) error) func(context.Context, *redis.Conn) error {
+	if prev == nil {
+		return next
+	}
+
+	return func(ctx context.Context, conn *redis.Conn) error {
+		if err := prev(ctx, conn); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// trackingUnsupported reports whether err is redis rejecting CLIENT TRACKING, rather than a connection error or the
+// subscriber having disconnected before the redirect
+func trackingUnsupported(err error) bool {
+	var redisErr redis.Error
+	return errors.As(err, &redisErr) && !strings.Contains(err.Error(), "does not exist")
+}
+
+// trackingNode returns the node a cached read of key is made through, or nil if the value should be read from redis
+// without caching. Tracked reads are always made from the primary, or the key's master on a cluster, as the
+// subscriber and reads connections must be on the same server.
+func (cli *Client) trackingNode(ctx context.Context, key string) *trackingNode {
+	if !cli.tracker.enabled() || callOptionsFrom(ctx).bypassCache {
+		return nil
+	}
+
+	switch client := cli.redisClient.(type) {
+	case *redis.Client:
+		return cli.tracker.node(client)
+	case *redis.ClusterClient:
+		master, err := client.MasterForKey(ctx, cli.key(key))
+		if err != nil {
+			return nil
+		}
+		return cli.tracker.node(master)
+	}
+
+	return nil
+}
+
+// getTrackedValue returns the value of key from memory if it is cached, otherwise it is read through node and cached.
+// If the read fails the reads connection may have been lost along with its tracking, so every value is evicted.
+func (cli *Client) getTrackedValue(ctx context.Context, node *trackingNode, key string) (string, error) {
+	val, err := cli.tracker.cache.getOrFetch(cli.key(key), func() (string, error) {
+		return cli.getValueFrom(ctx, node.reads, key)
+	})
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		cli.tracker.cache.InvalidateAll()
+	}
+
+	return val, err
+}
+
+// trackedWrites maps the commands that change the values of keys to the keys they change, taken from their arguments
+var trackedWrites = map[string]func(args []interface{}) []interface{}{
+	"set": firstArg, "setnx": firstArg, "setex": firstArg, "psetex": firstArg, "getset": firstArg, "getex": firstArg,
+	"getdel": firstArg, "append": firstArg, "setrange": firstArg, "incr": firstArg, "incrby": firstArg,
+	"incrbyfloat": firstArg, "decr": firstArg, "decrby": firstArg, "expire": firstArg, "pexpire": firstArg,
+	"expireat": firstArg, "pexpireat": firstArg,
+	"del": allArgs, "unlink": allArgs,
+	"mset": everyOtherArg, "msetnx": everyOtherArg,
+	"rename": firstTwoArgs, "renamenx": firstTwoArgs, "copy": firstTwoArgs,
+	"eval": scriptKeys, "evalsha": scriptKeys, "fcall": scriptKeys,
+}
+
+// firstArg returns the key of commands whose first argument is the only key written
+func firstArg(args []interface{}) []interface{} {
+	return args[1:min(2, len(args))]
+}
+
+// firstTwoArgs returns the keys of commands that write both their source and destination keys
+func firstTwoArgs(args []interface{}) []interface{} {
+	return args[1:min(3, len(args))]
+}
+
+// allArgs returns the keys of commands whose arguments are all keys
+func allArgs(args []interface{}) []interface{} {
+	return args[1:]
+}
+
+// everyOtherArg returns the keys of commands whose arguments alternate between keys and values
+func everyOtherArg(args []interface{}) []interface{} {
+	keys := make([]interface{}, 0, len(args)/2)
+	for i := 1; i < len(args); i += 2 {
+		keys = append(keys, args[i])
+	}
+	return keys
+}
+
+// scriptKeys returns the keys of EVAL, EVALSHA and FCALL, which follow the number of keys
+func scriptKeys(args []interface{}) []interface{} {
+	if len(args) < 3 {
+		return nil
+	}
+
+	numKeys, err := strconv.Atoi(fmt.Sprint(args[2]))
+	if err != nil || numKeys < 0 || 3+numKeys > len(args) {
+		return nil
+	}
+
+	return args[3 : 3+numKeys]
+}
+
+// DialHook does not change how connections are made
+func (t *tracker) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook evicts the keys changed by the command from the client-side cache, so that the client's next read sees
+// its own write without waiting for the invalidation from redis
+func (t *tracker) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		defer t.evictWritten(cmd)
+		return next(ctx, cmd)
+	}
+}
+
+// ProcessPipelineHook evicts the keys changed by every command in the pipeline from the client-side cache
+func (t *tracker) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		defer t.evictWritten(cmds...)
+		return next(ctx, cmds)
+	}
+}
+
+// evictWritten evicts the keys changed by cmds from the cache. The keys are evicted whether or not the commands
+// succeed, as a command that timed out may still have been applied.
+func (t *tracker) evictWritten(cmds ...redis.Cmder) {
+	for _, cmd := range cmds {
+		name := cmd.Name()
+		if name == "flushdb" || name == "flushall" {
+			t.cache.InvalidateAll()
+			continue
+		}
+
+		writtenArgs, isWrite := trackedWrites[name]
+		if !isWrite {
+			continue
+		}
+
+		var keys []string
+		for _, arg := range writtenArgs(cmd.Args()) {
+			if key, ok := arg.(string); ok {
+				keys = append(keys, key)
+			}
+		}
+		t.cache.Invalidate(keys...)
+	}
+}
+
+// TrackingEnabled reports whether values read with GetValue are being cached with server-assisted client-side caching.
+// It is false if ClientConfig.Tracking was not set, or if tracking is not supported by the server.
+func (cli *Client) TrackingEnabled() bool {
+	return cli.tracker.enabled()
+}
+
+// TrackingStats returns the hits, misses, evictions and invalidations of the client-side cache used when
+// ClientConfig.Tracking is set.
+func (cli *Client) TrackingStats() LocalCacheStats {
+	if cli.tracker == nil {
+		return LocalCacheStats{}
+	}
+
+	return cli.tracker.cache.Stats()
+}
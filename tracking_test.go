@@ -0,0 +1,314 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-redis/fake"
+	"github.com/ONSdigital/dis-redis/mocks"
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewTracker(t *testing.T) {
+	addHooks := func(redis.UniversalClient) {}
+
+	Convey("When a tracker is created in default mode", t, func() {
+		node := &trackingNode{tracker: newTracker(TrackingConfig{}, "search:", addHooks)}
+		node.redirectID.Store(7)
+
+		Convey("Then tracking is enabled for the keys the client reads and redirected to the subscriber", func() {
+			So(node.trackingArgs(), ShouldResemble, []interface{}{"client", "tracking", "on", "redirect", int64(7)})
+		})
+	})
+
+	Convey("When a tracker is created in broadcast mode with prefixes", t, func() {
+		node := &trackingNode{tracker: newTracker(TrackingConfig{Broadcast: true, Prefixes: []string{"nav:", "taxonomy:"}}, "search:", addHooks)}
+		node.redirectID.Store(7)
+
+		Convey("Then the prefixes are tracked within the client's namespace", func() {
+			So(node.trackingArgs(), ShouldResemble, []interface{}{
+				"client", "tracking", "on", "redirect", int64(7), "bcast", "prefix", "search:nav:", "prefix", "search:taxonomy:",
+			})
+		})
+	})
+
+	Convey("When a tracker is created in broadcast mode without prefixes", t, func() {
+		node := &trackingNode{tracker: newTracker(TrackingConfig{Broadcast: true}, "search:", addHooks)}
+		node.redirectID.Store(7)
+
+		Convey("Then the client's namespace is tracked", func() {
+			So(node.trackingArgs(), ShouldResemble, []interface{}{
+				"client", "tracking", "on", "redirect", int64(7), "bcast", "prefix", "search:",
+			})
+		})
+	})
+
+	Convey("When a tracking config has prefixes without broadcast mode", t, func() {
+		config := TrackingConfig{Prefixes: []string{"nav:"}}
+
+		Convey("Then it is invalid", func() {
+			So(config.Validate(), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestClient_Tracking(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a client with tracking enabled that is receiving invalidations", t, func() {
+		srv := fake.NewServer()
+		defer srv.Close()
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{KeyPrefix: testPrefix, Tracking: &TrackingConfig{}}, srv.NewClient())
+		defer client.Close(ctx)
+
+		writer := srv.NewClient()
+		defer writer.Close()
+
+		So(writer.Set(ctx, testPrefix+"nav", "home", 0).Err(), ShouldBeNil)
+		So(writer.Set(ctx, testPrefix+"taxonomy", "economy", 0).Err(), ShouldBeNil)
+		So(waitFor(func() bool { return client.trackingNode(ctx, "nav") != nil }), ShouldBeTrue)
+
+		Convey("When a key is read twice", func() {
+			_, _ = client.GetValue(ctx, "nav")
+			val, err := client.GetValue(ctx, "nav")
+
+			Convey("Then the second read is served from memory", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "home")
+				So(client.TrackingEnabled(), ShouldBeTrue)
+				So(client.TrackingStats(), ShouldResemble, LocalCacheStats{Hits: 1, Misses: 1})
+			})
+		})
+
+		Convey("When a cached key is read without the cache", func() {
+			_, _ = client.GetValue(ctx, "nav")
+			val, err := client.GetValue(ctx, "nav", WithoutCache())
+
+			Convey("Then it is read from redis", func() {
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "home")
+				So(client.TrackingStats(), ShouldResemble, LocalCacheStats{Misses: 1})
+			})
+		})
+
+		Convey("When a cached key is changed by another client", func() {
+			_, _ = client.GetValue(ctx, "nav")
+			So(writer.Set(ctx, testPrefix+"nav", "about", 0).Err(), ShouldBeNil)
+
+			Convey("Then it is evicted without the client using its connections, and the new value is read", func() {
+				So(waitFor(func() bool { return client.tracker.cache.Len() == 0 }), ShouldBeTrue)
+
+				val, err := client.GetValue(ctx, "nav")
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "about")
+			})
+		})
+
+		Convey("When redis flushes its keyspace", func() {
+			_, _ = client.GetValue(ctx, "nav")
+			_, _ = client.GetValue(ctx, "taxonomy")
+			srv.FlushAll()
+
+			Convey("Then the cache is emptied", func() {
+				So(waitFor(func() bool { return client.tracker.cache.Len() == 0 }), ShouldBeTrue)
+
+				_, err := client.GetValue(ctx, "nav")
+				So(err, ShouldEqual, ErrKeyNotFound)
+			})
+		})
+
+		Convey("When a cached key is written by the client", func() {
+			_, _ = client.GetValue(ctx, "nav")
+			So(client.SetValue(ctx, "nav", "new", 0), ShouldBeNil)
+
+			Convey("Then it is evicted without waiting for redis", func() {
+				So(client.tracker.cache.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the connections are lost and a cached key changes before they reconnect", func() {
+			_, _ = client.GetValue(ctx, "nav")
+			srv.DisconnectClients()
+			_ = writer.Ping(ctx)
+			So(writer.Set(ctx, testPrefix+"nav", "contact", 0).Err(), ShouldBeNil)
+
+			Convey("Then the cache is emptied and caching resumes once invalidations are received again", func() {
+				So(waitFor(func() bool { return client.tracker.cache.Len() == 0 }), ShouldBeTrue)
+				So(waitFor(func() bool { return client.trackingNode(ctx, "nav") != nil }), ShouldBeTrue)
+
+				val, err := client.GetValue(ctx, "nav")
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "contact")
+				So(client.tracker.cache.Len(), ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the client is closed", func() {
+			So(client.Close(ctx), ShouldBeNil)
+
+			Convey("Then values are no longer cached", func() {
+				So(client.trackingNode(ctx, "nav"), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a client with tracking enabled on a server that does not support it", t, func() {
+		client := NewClientWithCustomClient(ctx, &ClientConfig{Tracking: &TrackingConfig{}}, &mocks.GoRedisClientMock{})
+		redisClient, _ := newStubbedClient(func(cmd redis.Cmder) {
+			cmd.SetErr(testRedisError("ERR Unknown subcommand 'TRACKING'"))
+		})
+		conn := redisClient.Conn()
+		defer conn.Close()
+
+		Convey("When tracking is enabled on the connection for tracked reads", func() {
+			node := &trackingNode{tracker: client.tracker}
+			err := node.onReadsConnect(ctx, conn)
+
+			Convey("Then the connection is kept but caching is disabled", func() {
+				So(err, ShouldBeNil)
+				So(client.TrackingEnabled(), ShouldBeFalse)
+				So(client.trackingNode(ctx, "nav"), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a client with cached values that redis does not send invalidations for", t, func() {
+		srv := fake.NewServer()
+		defer srv.Close()
+
+		writer := srv.NewClient()
+		defer writer.Close()
+
+		// Only the tracker's hook can evict the cached values, as tracking is not enabled on the server
+		redisClient := srv.NewClient()
+		client := &Client{redisClient: redisClient, keyPrefix: testPrefix}
+		client.tracker = newTracker(TrackingConfig{}, testPrefix, client.addHooks)
+		client.addHooks(redisClient)
+		client.RegisterScript("set", "return redis.call('SET', KEYS[1], ARGV[1])")
+
+		read := func(key string) (string, error) {
+			return client.tracker.cache.getOrFetch(client.key(key), func() (string, error) {
+				return client.getValueFrom(ctx, redisClient, key)
+			})
+		}
+
+		So(writer.Set(ctx, testPrefix+"nav", "home", 0).Err(), ShouldBeNil)
+		So(writer.Set(ctx, testPrefix+"count", "1", 0).Err(), ShouldBeNil)
+		_, _ = read("nav")
+		_, _ = read("count")
+
+		writes := []struct {
+			path  string
+			key   string
+			write func() error
+			want  string
+		}{
+			{path: "SetValue", key: "nav", want: "new", write: func() error {
+				return client.SetValue(ctx, "nav", "new", 0)
+			}},
+			{path: "DeleteValue", key: "nav", write: func() error {
+				return client.DeleteValue(ctx, "nav")
+			}},
+			{path: "SetNX", key: "nav", want: "new", write: func() error {
+				if err := writer.Del(ctx, testPrefix+"nav").Err(); err != nil {
+					return err
+				}
+				_, err := client.SetNX(ctx, "nav", "new", 0)
+				return err
+			}},
+			{path: "SetXX", key: "nav", want: "new", write: func() error {
+				_, err := client.SetXX(ctx, "nav", "new", 0)
+				return err
+			}},
+			{path: "SetKeepTTL", key: "nav", want: "new", write: func() error {
+				return client.SetKeepTTL(ctx, "nav", "new")
+			}},
+			{path: "SetGet", key: "nav", want: "new", write: func() error {
+				_, _, err := client.SetGet(ctx, "nav", "new", 0)
+				return err
+			}},
+			{path: "GetEx", key: "nav", want: "home", write: func() error {
+				_, err := client.GetEx(ctx, "nav", time.Minute)
+				return err
+			}},
+			{path: "Incr", key: "count", want: "2", write: func() error {
+				_, err := client.Incr(ctx, "count", 0)
+				return err
+			}},
+			{path: "IncrBy", key: "count", want: "3", write: func() error {
+				_, err := client.IncrBy(ctx, "count", 2, 0)
+				return err
+			}},
+			{path: "IncrByFloat", key: "count", want: "1.5", write: func() error {
+				_, err := client.IncrByFloat(ctx, "count", 0.5, 0)
+				return err
+			}},
+			{path: "SetValues", key: "nav", want: "new", write: func() error {
+				_, err := client.SetValues(ctx, map[string]interface{}{"nav": "new"}, 0)
+				return err
+			}},
+			{path: "DeleteValues", key: "nav", write: func() error {
+				_, err := client.DeleteValues(ctx, []string{"nav"})
+				return err
+			}},
+			{path: "DeleteByPattern", key: "nav", write: func() error {
+				_, err := client.DeleteByPattern(ctx, "na*", DeleteByPatternOptions{})
+				return err
+			}},
+			{path: "Tx.SetValue", key: "nav", want: "new", write: func() error {
+				return client.Transaction(ctx, []string{"nav"}, func(tx *Tx) error {
+					tx.SetValue("nav", "new", 0)
+					return nil
+				})
+			}},
+			{path: "Tx.DeleteValue", key: "nav", write: func() error {
+				return client.Transaction(ctx, []string{"nav"}, func(tx *Tx) error {
+					tx.DeleteValue("nav")
+					return nil
+				})
+			}},
+			// The fake server does not run scripts, but the key is evicted once the script has been sent
+			{path: "RunScript", key: "nav", want: "home", write: func() error {
+				_, _ = client.RunScript(ctx, "set", []string{"nav"}, "new")
+				return nil
+			}},
+		}
+
+		for _, w := range writes {
+			Convey("When a cached key is written with "+w.path, func() {
+				So(w.write(), ShouldBeNil)
+				val, err := read(w.key)
+
+				Convey("Then it is evicted and read from redis again", func() {
+					So(client.tracker.cache.Stats().Invalidations, ShouldEqual, 1)
+					if w.want == "" {
+						So(err, ShouldEqual, ErrKeyNotFound)
+					} else {
+						So(err, ShouldBeNil)
+						So(val, ShouldEqual, w.want)
+					}
+				})
+			})
+		}
+
+		Convey("When the keyspace is flushed by the client", func() {
+			So(redisClient.FlushDB(ctx).Err(), ShouldBeNil)
+
+			Convey("Then every cached key is evicted", func() {
+				So(client.tracker.cache.Len(), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a client without tracking", t, func() {
+		client := &Client{}
+
+		Convey("Then tracking is reported as disabled", func() {
+			So(client.TrackingEnabled(), ShouldBeFalse)
+			So(client.TrackingStats(), ShouldResemble, LocalCacheStats{})
+		})
+	})
+}
@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultTransactionMaxRetries = 5
+	transactionMinBackoff        = 10 * time.Millisecond
+	transactionMaxBackoff        = time.Second
+)
+
+// Tx is a transaction started by Client.Transaction. Reads are made immediately against the watched keys, while
+// writes are queued and applied atomically when the transaction function returns nil.
+type Tx struct {
+	client *Client
+	tx     *redis.Tx
+	writes []func(ctx context.Context, pipe redis.Pipeliner) error
+}
+
+// Transaction runs fn inside an optimistic transaction over the given keys. The keys are watched before fn is called,
+// and the writes queued by fn are only applied if none of the keys were modified in the meantime. If they were, fn is
+// run again with backoff, up to the client's TransactionMaxRetries, before ErrTransactionConflict is returned.
+//
+// On cluster clients all keys must share a hash slot, for example by using a hash tag such as "{quota}:user1",
+// otherwise ErrCrossSlot is returned. Any error returned by fn aborts the transaction and is returned unchanged.
+func (cli *Client) Transaction(ctx context.Context, keys []string, fn func(tx *Tx) error) error {
+	if len(keys) == 0 {
+		return errors.New("at least one key must be watched")
+	}
+
+	watchKeys := cli.keys(keys)
+
+	if _, isCluster := cli.redisClient.(*redis.ClusterClient); isCluster {
+		slot := hashSlot(watchKeys[0])
+		for _, key := range watchKeys[1:] {
+			if hashSlot(key) != slot {
+				return fmt.Errorf("%w: transaction keys %v", ErrCrossSlot, keys)
+			}
+		}
+	}
+
+	maxRetries := cli.transactionMaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultTransactionMaxRetries
+	}
+
+	backoff := transactionMinBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := cli.redisClient.Watch(ctx, func(rtx *redis.Tx) error {
+			tx := &Tx{client: cli, tx: rtx}
+			if err := fn(tx); err != nil {
+				return err
+			}
+
+			return tx.exec(ctx)
+		}, watchKeys...)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+
+		if attempt >= maxRetries {
+			return fmt.Errorf("%w: gave up after %d attempts", ErrTransactionConflict, attempt+1)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, transactionMaxBackoff)
+	}
+}
+
+// GetValue retrieves the current value of key. Watched keys are read before any of the transaction's writes are applied.
+func (tx *Tx) GetValue(ctx context.Context, key string) (string, error) {
+	val, err := tx.tx.Get(ctx, tx.client.key(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrKeyNotFound
+	} else if err != nil {
+		return "", fmt.Errorf("error getting value for key %s: %w", key, err)
+	}
+
+	val, err = tx.client.decodeValue(ctx, tx.client.key(key), val)
+	if err != nil {
+		return "", fmt.Errorf("error decoding value for key %s: %w", key, err)
+	}
+
+	return val, nil
+}
+
+// SetValue queues setting a key-value pair with an optional expiration time when the transaction commits.
+// If the value cannot be encoded the transaction is aborted with the error when it commits.
+func (tx *Tx) SetValue(key string, value interface{}, expiration time.Duration) {
+	tx.writes = append(tx.writes, func(ctx context.Context, pipe redis.Pipeliner) error {
+		value, err := tx.client.encodeValue(ctx, tx.client.key(key), value)
+		if err != nil {
+			return fmt.Errorf("error encoding value for key %s: %w", key, err)
+		}
+
+		pipe.Set(ctx, tx.client.key(key), value, expiration)
+		return nil
+	})
+}
+
+// DeleteValue queues deleting key when the transaction commits.
+func (tx *Tx) DeleteValue(key string) {
+	tx.writes = append(tx.writes, func(ctx context.Context, pipe redis.Pipeliner) error {
+		pipe.Del(ctx, tx.client.key(key))
+		return nil
+	})
+}
+
+// exec applies the queued writes with MULTI/EXEC
+func (tx *Tx) exec(ctx context.Context) error {
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	_, err := tx.tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, write := range tx.writes {
+			if err := write(ctx, pipe); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.TxFailedErr) {
+		return fmt.Errorf("error executing transaction: %w", err)
+	}
+
+	return err
+}
@@ -0,0 +1,104 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClient_Transaction(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a counter whose watched key is modified by another client once", t, func() {
+		conflicts := 1
+		var commands [][]interface{}
+
+		redisClient, _ := newStubbedClient(func(cmd redis.Cmder) {
+			commands = append(commands, cmd.Args())
+			switch cmd.Name() {
+			case "get":
+				cmd.(*redis.StringCmd).SetVal("41")
+			case "exec":
+				if conflicts > 0 {
+					conflicts--
+					cmd.SetErr(redis.TxFailedErr)
+				}
+			}
+		})
+
+		client := NewClientWithCustomClient(ctx, &ClientConfig{}, redisClient)
+
+		Convey("When the counter is incremented in a transaction", func() {
+			attempts := 0
+			err := client.Transaction(ctx, []string{"counter"}, func(tx *Tx) error {
+				attempts++
+				val, err := tx.GetValue(ctx, "counter")
+				if err != nil {
+					return err
+				}
+				n, _ := strconv.Atoi(val)
+				tx.SetValue("counter", n+1, 0)
+				return nil
+			})
+
+			Convey("Then it is retried and the write is applied inside MULTI/EXEC", func() {
+				So(err, ShouldBeNil)
+				So(attempts, ShouldEqual, 2)
+				So(commands[0], ShouldResemble, []interface{}{"watch", "counter"})
+				So(commands, ShouldContain, []interface{}{"set", "counter", 42})
+				So(commands, ShouldContain, []interface{}{"multi"})
+			})
+		})
+
+		Convey("When the watched key keeps being modified", func() {
+			conflicts = 10
+			client.transactionMaxRetries = 2
+			attempts := 0
+
+			err := client.Transaction(ctx, []string{"counter"}, func(tx *Tx) error {
+				attempts++
+				tx.DeleteValue("counter")
+				return nil
+			})
+
+			Convey("Then it gives up with a conflict error", func() {
+				So(errors.Is(err, ErrTransactionConflict), ShouldBeTrue)
+				So(attempts, ShouldEqual, 3)
+			})
+		})
+
+		Convey("When the transaction function returns an error", func() {
+			fnErr := errors.New("quota exceeded")
+
+			err := client.Transaction(ctx, []string{"counter"}, func(tx *Tx) error {
+				tx.SetValue("counter", 0, 0)
+				return fnErr
+			})
+
+			Convey("Then the error is returned and no writes are made", func() {
+				So(err, ShouldEqual, fnErr)
+				So(commands, ShouldNotContain, []interface{}{"multi"})
+			})
+		})
+	})
+
+	Convey("Given a cluster client", t, func() {
+		client := &Client{
+			redisClient: redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{testAddress}}),
+		}
+
+		Convey("When a transaction watches keys in different slots", func() {
+			err := client.Transaction(ctx, []string{"quota:user1", "quota:user2"}, func(tx *Tx) error {
+				return nil
+			})
+
+			Convey("Then a cross slot error is returned", func() {
+				So(errors.Is(err, ErrCrossSlot), ShouldBeTrue)
+			})
+		})
+	})
+}
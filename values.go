@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+)
+
+// Values encoded by the client start with a header of valueMarker followed by a byte identifying the encoding. The
+// marker starts with bytes that can never begin a valid UTF-8 string and is long enough that a binary value written
+// without encoding will not start with it by chance, so values written without encoding remain readable.
+const valueMarker = "\xF5\xC0DR"
+
+const (
+	headerGzip      byte = 'g'
+	headerZstd      byte = 'z'
+	headerSnappy    byte = 's'
+	headerEncrypted byte = 'e'
+)
+
+// valueHeader returns the header of a value with the encoding
+func valueHeader(encoding byte) []byte {
+	return append([]byte(valueMarker), encoding)
+}
+
+// parseHeader returns the encoding of val and the data following its header, or false if val has no header
+func parseHeader(val string) (encoding byte, data string, encoded bool) {
+	if len(val) <= len(valueMarker) || val[:len(valueMarker)] != valueMarker {
+		return 0, val, false
+	}
+
+	return val[len(valueMarker)], val[len(valueMarker)+1:], true
+}
+
+// encodeValue applies the client's value encodings, compression and then encryption, to a value about to be written to
+// redisKey, the key including the client's KeyPrefix.
+// Only string, []byte and encoding.BinaryMarshaler values are encoded. Other values are written as they are, unless the
+// client encrypts values, in which case they are rejected rather than written in plaintext.
+func (cli *Client) encodeValue(ctx context.Context, redisKey string, value interface{}) (interface{}, error) {
+	if cli.compression == CompressionNone && cli.keyProvider == nil {
+		return value, nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case encoding.BinaryMarshaler:
+		b, err := v.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling value: %w", err)
+		}
+		data = b
+	default:
+		if cli.keyProvider != nil {
+			return nil, fmt.Errorf("values of type %T cannot be encrypted", value)
+		}
+		return value, nil
+	}
+
+	encoded := value
+
+	if cli.compression != CompressionNone && len(data) >= cli.compressionMinSize() {
+		compressed, err := cli.compression.compress(data)
+		if err != nil {
+			return nil, fmt.Errorf("error compressing value: %w", err)
+		}
+
+		// Keep values that do not compress well as they are
+		if len(compressed) < len(data) {
+			data = compressed
+			encoded = compressed
+		}
+	}
+
+	if cli.keyProvider != nil {
+		encrypted, err := encrypt(ctx, cli.keyProvider, redisKey, data)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting value: %w", err)
+		}
+		encoded = encrypted
+	}
+
+	return encoded, nil
+}
+
+// decodeValue reverses the encodings of a value read from redisKey. Values without a header are returned unchanged.
+// Compressed values are decompressed whatever the client's Compression, and encrypted values can only be read by a
+// client with a KeyProvider.
+func (cli *Client) decodeValue(ctx context.Context, redisKey, val string) (string, error) {
+	encoding, data, encoded := parseHeader(val)
+	if encoded && encoding == headerEncrypted {
+		if cli.keyProvider == nil {
+			return "", errors.New("value is encrypted but the client has no key provider")
+		}
+
+		decrypted, err := decrypt(ctx, cli.keyProvider, redisKey, []byte(data))
+		if err != nil {
+			return "", fmt.Errorf("error decrypting value: %w", err)
+		}
+		val = string(decrypted)
+		encoding, data, encoded = parseHeader(val)
+	}
+
+	if !encoded {
+		return val, nil
+	}
+
+	switch encoding {
+	case headerGzip, headerZstd, headerSnappy:
+		decompressed, err := decompress(encoding, []byte(data))
+		if err != nil {
+			return "", fmt.Errorf("error decompressing value: %w", err)
+		}
+		return string(decompressed), nil
+	}
+
+	return val, nil
+}
+
+// compressionMinSize returns the minimum size in bytes of a value to compress
+func (cli *Client) compressionMinSize() int {
+	if cli.compressionThreshold <= 0 {
+		return defaultCompressionThreshold
+	}
+
+	return cli.compressionThreshold
+}